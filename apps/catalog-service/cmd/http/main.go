@@ -1,29 +1,285 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"jrobic/lawn-mower/catalog-service/domain"
+	"jrobic/lawn-mower/catalog-service/infra/config"
+	grpccontroller "jrobic/lawn-mower/catalog-service/infra/grpc"
+	"jrobic/lawn-mower/catalog-service/infra/healthcheck"
 	restcontroller "jrobic/lawn-mower/catalog-service/infra/http"
+	"jrobic/lawn-mower/catalog-service/infra/idgen"
+	"jrobic/lawn-mower/catalog-service/infra/lifecycle"
 	"jrobic/lawn-mower/catalog-service/infra/repository"
+	"jrobic/lawn-mower/catalog-service/infra/search"
+	"jrobic/lawn-mower/catalog-service/usecase"
 	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
 func main() {
-	repo := repository.NewInMemoryRepo([]*domain.Mower{
-		{ID: "1", Name: "M-90"},
-		{ID: "2", Name: "M-150"},
-		{ID: "3", Name: "M-480"},
-	})
+	cfg, printConfig, err := config.Load(os.Args[1:], os.Getenv)
 
-	server, err := restcontroller.NewCatalogHTTPServer(repo)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if printConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+
+		return
+	}
+
+	ids, err := idgen.New(cfg.Repository.IDFormat)
+
+	if err != nil {
+		log.Fatalf("problem creating id generator %v", err)
+	}
+
+	backend, err := newBackend(context.Background(), cfg.Repository, ids)
+
+	if err != nil {
+		log.Fatalf("problem creating catalog repository %v", err)
+	}
+
+	var cache *repository.CachedRepo
+
+	if cfg.Cache.Enabled {
+		cache = repository.NewCachedRepo(backend.repo, repository.CacheOptions{
+			Size:        cfg.Cache.Size,
+			TTL:         cfg.Cache.TTL,
+			NegativeTTL: cfg.Cache.NegativeTTL,
+		})
+		backend.repo = cache
+	}
+
+	health := healthcheck.NewHealthChecker()
+
+	if backend.ping != nil {
+		health.Register("postgres", 0, backend.ping)
+	}
+
+	opts := []restcontroller.ServerOption{
+		restcontroller.WithHealthChecker(health),
+		restcontroller.WithStoreRepository(backend.stores),
+		restcontroller.WithInventoryRepository(backend.inventory),
+		restcontroller.WithSearchIndex(backend.index),
+		restcontroller.WithAdminToken(cfg.AdminToken),
+		restcontroller.WithIDGenerator(ids),
+		restcontroller.WithTimeouts(cfg.HTTP.ReadTimeout, cfg.HTTP.WriteTimeout, cfg.HTTP.IdleTimeout),
+		restcontroller.WithRequestTimeouts(cfg.HTTP.RequestTimeout, cfg.HTTP.QueryTimeout),
+		restcontroller.WithDrainDelay(cfg.DrainDelay),
+	}
+
+	if cfg.RequireIfMatch {
+		opts = append(opts, restcontroller.WithRequiredIfMatch())
+	}
+
+	if cfg.LogLevel == config.LogLevelDebug {
+		opts = append(opts, restcontroller.WithRequestLogging())
+	}
+
+	server, err := restcontroller.NewCatalogHTTPServer(backend.repo, opts...)
 
 	if err != nil {
 		log.Fatalf("problem creating player server %v", err)
 	}
 
-	log.Println("Listen on port 5001")
+	grpcOpts := []grpccontroller.ServerOption{grpccontroller.WithHealthChecker(health)}
+
+	if cfg.GRPC.Reflection {
+		grpcOpts = append(grpcOpts, grpccontroller.WithReflection())
+	}
+
+	grpcServer := grpccontroller.NewCatalogGRPCServer(usecase.NewCatalogService(backend.repo,
+		usecase.WithStoreRepository(backend.stores),
+		usecase.WithInventoryRepository(backend.inventory),
+		usecase.WithSearchIndex(backend.index),
+	), grpcOpts...)
+
+	lc := lifecycle.New(cfg.ShutdownTimeout)
+
+	if backend.close != nil {
+		lc.Append(lifecycle.Hook{Name: "repository", OnStop: func(context.Context) error {
+			return backend.close()
+		}})
+	}
+
+	if backend.flush != nil {
+		lc.Append(lc.Worker("journal flusher", backend.flush))
+	}
+
+	if cache != nil {
+		lc.Append(cacheHook(cache))
+	}
+
+	lc.Append(lc.Worker("health checks", func(ctx context.Context) {
+		grpcServer.WatchHealth(ctx, cfg.HealthCheckInterval)
+	}))
+	lc.Append(grpcHook(lc, grpcServer, cfg.GRPC.Addr))
+	lc.Append(httpHook(lc, server, cfg.HTTP.Addr))
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := lc.Run(ctx); err != nil {
+		log.Fatalf("catalog service stopped %v", err)
+	}
+
+	log.Println("catalog service stopped")
+}
+
+// httpHook serves server on addr, draining the requests in flight on stop.
+func httpHook(lc *lifecycle.Lifecycle, server *restcontroller.CatalogHTTPServer, addr string) lifecycle.Hook {
+	return lifecycle.Hook{
+		Name: "http server",
+		OnStart: func(context.Context) error {
+			listener, err := net.Listen("tcp", addr)
+
+			if err != nil {
+				return err
+			}
+
+			log.Printf("Listen on %s", listener.Addr())
+
+			lc.Go("http server", func() error {
+				return server.App.Listener(listener)
+			})
+
+			return nil
+		},
+		OnStop: server.Shutdown,
+	}
+}
+
+// grpcHook serves server on addr, draining the calls in flight on stop.
+func grpcHook(lc *lifecycle.Lifecycle, server *grpccontroller.CatalogGRPCServer, addr string) lifecycle.Hook {
+	return lifecycle.Hook{
+		Name: "grpc server",
+		OnStart: func(context.Context) error {
+			listener, err := net.Listen("tcp", addr)
+
+			if err != nil {
+				return err
+			}
+
+			log.Printf("gRPC listen on %s", listener.Addr())
+
+			lc.Go("grpc server", func() error {
+				return server.Server.Serve(listener)
+			})
+
+			return nil
+		},
+		OnStop: server.Shutdown,
+	}
+}
+
+// cacheHook reports how well cache did once the servers stopped.
+func cacheHook(cache *repository.CachedRepo) lifecycle.Hook {
+	return lifecycle.Hook{
+		Name: "mower cache",
+		OnStop: func(context.Context) error {
+			stats := cache.Stats()
+			log.Printf("mower cache served %d hits and %d misses", stats.Hits, stats.Misses)
+
+			return nil
+		},
+	}
+}
+
+// backend holds the repositories and search index the service runs on, and
+// how to check the database behind them, if any, and close them.
+type backend struct {
+	repo      domain.CatalogRepository
+	stores    domain.StoreRepository
+	inventory domain.InventoryRepository
+	index     domain.SearchIndex
+	ping      func(ctx context.Context) error
+	// flush, when set, runs until ctx is done to flush the journal in the
+	// background.
+	flush func(ctx context.Context)
+	close func() error
+}
+
+// newBackend builds the backend of cfg: a Postgres database, or a seeded
+// in-memory catalog, journaled in a directory by the file backend, searched
+// through an inverted index. The journal only holds mowers, so the file
+// backend goes without stores and inventory rather than lose them on
+// restart: their routes answer 503 Service Unavailable.
+func newBackend(ctx context.Context, cfg config.RepositoryConfig, ids domain.IDGenerator) (*backend, error) {
+	if cfg.Backend == config.BackendPostgres {
+		db, err := repository.OpenPostgres(cfg.DatabaseURL)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &backend{
+			repo:      repository.NewPostgresRepo(db, repository.WithIDGenerator(ids)),
+			stores:    repository.NewPostgresStoreRepo(db, repository.WithIDGenerator(ids)),
+			inventory: repository.NewPostgresInventoryRepo(db, repository.WithIDGenerator(ids)),
+			index:     repository.NewPostgresSearchIndex(db),
+			ping:      db.PingContext,
+			close:     db.Close,
+		}, nil
+	}
+
+	opts := []repository.Option{repository.WithIDGenerator(ids)}
+	seed := true
+
+	if cfg.Backend == config.BackendFile {
+		journal, err := repository.OpenJournal(cfg.DataDir, repository.JournalOptions{
+			Sync:          repository.SyncPolicy(cfg.Fsync),
+			SyncInterval:  cfg.FsyncInterval,
+			SnapshotEvery: cfg.SnapshotEvery,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, repository.WithJournal(journal))
+		seed = journal.Created()
+	}
+
+	repo := repository.NewInMemoryRepo([]*domain.Mower{}, opts...)
+
+	if seed {
+		for _, name := range cfg.SeedMowers {
+			if _, err := repo.Add(ctx, domain.CreateMowerDTO{Name: name}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	index, err := search.BuildInvertedIndex(ctx, repo)
+
+	if err != nil {
+		return nil, err
+	}
+
+	b := &backend{repo: repo, index: index, close: repo.Close}
+
+	if cfg.Backend == config.BackendFile && repository.SyncPolicy(cfg.Fsync) == repository.SyncInterval {
+		b.flush = func(ctx context.Context) { repo.FlushJournal(ctx, cfg.FsyncInterval) }
+	}
 
-	if err := server.App.Listen(":5001"); err != nil {
-		log.Fatalf("could not listen on port 5001 %v", err)
+	if cfg.Backend != config.BackendFile {
+		b.stores = repository.NewInMemoryStoreRepo([]*domain.Store{}, repository.WithIDGenerator(ids))
+		b.inventory = repository.NewInMemoryInventoryRepo([]*domain.InventoryUnit{},
+			repository.WithIDGenerator(ids), repository.WithCatalog(repo))
 	}
 
+	return b, nil
 }
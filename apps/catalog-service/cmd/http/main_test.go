@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"io"
+	lmTesting "jrobic/lawn-mower/catalog-service"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"jrobic/lawn-mower/catalog-service/infra/config"
+	restcontroller "jrobic/lawn-mower/catalog-service/infra/http"
+	"jrobic/lawn-mower/catalog-service/infra/idgen"
+	"jrobic/lawn-mower/catalog-service/infra/lifecycle"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// slowRepo takes delay to find a mower, telling started when it is asked to.
+type slowRepo struct {
+	domain.CatalogRepository
+	delay   time.Duration
+	started chan struct{}
+}
+
+func (r *slowRepo) Find(ctx context.Context, id string) (*domain.Mower, error) {
+	close(r.started)
+	time.Sleep(r.delay)
+
+	return r.CatalogRepository.Find(ctx, id)
+}
+
+func freeAddr(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	lmTesting.AssertNoError(t, err)
+
+	defer listener.Close()
+
+	return listener.Addr().String()
+}
+
+func TestGracefulShutdown(t *testing.T) {
+	repo := &slowRepo{
+		CatalogRepository: &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{{ID: "1", Name: "M-90"}}},
+		delay:             300 * time.Millisecond,
+		started:           make(chan struct{}),
+	}
+
+	server, err := restcontroller.NewCatalogHTTPServer(repo, restcontroller.WithDrainDelay(200*time.Millisecond))
+	lmTesting.AssertNoError(t, err)
+
+	addr := freeAddr(t)
+	ctx, stop := context.WithCancel(context.Background())
+	closed := false
+
+	lc := lifecycle.New(5 * time.Second)
+	lc.Append(lifecycle.Hook{Name: "database", OnStop: func(context.Context) error {
+		closed = true
+		return nil
+	}})
+	lc.Append(httpHook(lc, server, addr))
+
+	stopped := make(chan error, 1)
+
+	go func() {
+		stopped <- lc.Run(ctx)
+	}()
+
+	type result struct {
+		status int
+		body   string
+		err    error
+	}
+
+	inFlight := make(chan result, 1)
+
+	go func() {
+		var res *http.Response
+		var err error
+
+		// the server may not listen yet
+		for i := 0; i < 50; i++ {
+			if res, err = http.Get("http://" + addr + "/mowers/1"); err == nil {
+				break
+			}
+
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		if err != nil {
+			inFlight <- result{err: err}
+			return
+		}
+
+		defer res.Body.Close()
+
+		body, err := io.ReadAll(res.Body)
+		inFlight <- result{status: res.StatusCode, body: string(body), err: err}
+	}()
+
+	select {
+	case <-repo.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the request never reached the repository")
+	}
+
+	stop()
+
+	// load balancers see the service draining while it still serves
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(5 * time.Millisecond) {
+		res, err := http.Get("http://" + addr + "/readyz")
+
+		if err != nil {
+			t.Fatalf("the server stopped accepting connections while draining: %v", err)
+		}
+
+		res.Body.Close()
+
+		if res.StatusCode == http.StatusServiceUnavailable {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("got /readyz %d while draining want %d", res.StatusCode, http.StatusServiceUnavailable)
+		}
+	}
+
+	got := <-inFlight
+
+	lmTesting.AssertNoError(t, got.err)
+	lmTesting.AssertStatus(t, got.status, http.StatusOK)
+
+	if !strings.Contains(got.body, "M-90") {
+		t.Errorf("got body %s want mower M-90", got.body)
+	}
+
+	select {
+	case err := <-stopped:
+		lmTesting.AssertNoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the service did not stop")
+	}
+
+	if !closed {
+		t.Error("expected the database to be closed")
+	}
+
+	if _, err := http.Get("http://" + addr + "/healthz"); err == nil {
+		t.Error("expected the server to refuse new connections")
+	}
+}
+
+func TestFileBackend(t *testing.T) {
+	cfg := config.RepositoryConfig{Backend: config.BackendFile, DataDir: t.TempDir(), SeedMowers: []string{"M-90"}}
+
+	b, err := newBackend(context.Background(), cfg, idgen.NewSequential(0))
+	lmTesting.AssertNoError(t, err)
+
+	defer b.close()
+
+	server, err := restcontroller.NewCatalogHTTPServer(b.repo,
+		restcontroller.WithStoreRepository(b.stores), restcontroller.WithInventoryRepository(b.inventory))
+	lmTesting.AssertNoError(t, err)
+
+	// stores and inventory would not survive a restart
+	request, _ := http.NewRequest(http.MethodPost, "/stores", strings.NewReader(`{"name":"Lyon"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	response, _ := server.App.Test(request, -1)
+
+	lmTesting.AssertStatus(t, response.StatusCode, http.StatusServiceUnavailable)
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"jrobic/lawn-mower/catalog-service/infra/migration"
+	"log"
+	"os"
+	"strconv"
+
+	_ "github.com/lib/pq"
+)
+
+const usage = `usage: migrate [flags] <command>
+
+commands:
+  up              apply all pending migrations
+  down N          revert the N most recent migrations
+  status          list migrations and whether they are applied
+  create NAME     add an empty up/down pair to -dir
+  force VERSION   mark VERSION as cleanly applied after a manual fix
+
+flags:
+`
+
+func main() {
+	dsn := flag.String("dsn", os.Getenv("CATALOG_DATABASE_URL"), "postgres connection string")
+	dir := flag.String("dir", "infra/migration/sql", "migrations source directory used by create")
+
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	args := flag.Args()
+
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if args[0] == "create" {
+		if len(args) != 2 {
+			log.Fatal("create needs a migration name")
+		}
+
+		paths, err := migration.Create(*dir, args[1])
+
+		if err != nil {
+			log.Fatalf("could not create migration %v", err)
+		}
+
+		for _, path := range paths {
+			fmt.Println(path)
+		}
+
+		return
+	}
+
+	migrations, err := migration.Embedded()
+
+	if err != nil {
+		log.Fatalf("could not load migrations %v", err)
+	}
+
+	db, err := sql.Open("postgres", *dsn)
+
+	if err != nil {
+		log.Fatalf("could not open database %v", err)
+	}
+
+	defer db.Close()
+
+	if err := run(context.Background(), migration.NewMigrator(db, migrations), args); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(ctx context.Context, migrator *migration.Migrator, args []string) error {
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+
+		for _, m := range applied {
+			fmt.Printf("applied %04d_%s\n", m.Version, m.Name)
+		}
+
+		return err
+
+	case "down":
+		n := 1
+
+		if len(args) > 1 {
+			var err error
+
+			if n, err = strconv.Atoi(args[1]); err != nil || n < 1 {
+				return fmt.Errorf("down needs a positive number of migrations, got %q", args[1])
+			}
+		}
+
+		reverted, err := migrator.Down(ctx, n)
+
+		for _, m := range reverted {
+			fmt.Printf("reverted %04d_%s\n", m.Version, m.Name)
+		}
+
+		return err
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		for _, s := range statuses {
+			state := "pending"
+
+			if s.Dirty {
+				state = "dirty"
+			} else if s.Applied {
+				state = "applied " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+
+			fmt.Printf("%04d_%-30s %s\n", s.Version, s.Name, state)
+		}
+
+		return nil
+
+	case "force":
+		if len(args) != 2 {
+			return fmt.Errorf("force needs a version")
+		}
+
+		version, err := strconv.ParseUint(args[1], 10, 64)
+
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+
+		return migrator.Force(ctx, version)
+	}
+
+	return fmt.Errorf("unknown command %q", args[0])
+}
@@ -1,5 +1,94 @@
 package domain
 
+import (
+	"errors"
+	"fmt"
+)
+
+// Error kinds. Every catalog error matches one of them with errors.Is, which
+// is how adapters decide how to report it.
 var (
-	ErrMowerNotFound = "[Catalog] Mower with id `%v` not found!"
+	ErrNotFound    = errors.New("[Catalog] Not found")
+	ErrConflict    = errors.New("[Catalog] Conflict")
+	ErrValidation  = errors.New("[Catalog] Validation failed")
+	ErrUnavailable = errors.New("[Catalog] Unavailable")
+	// ErrMalformedQuery is for requests that cannot be understood as sent,
+	// e.g. unknown listing parameters.
+	ErrMalformedQuery = errors.New("[Catalog] Malformed query")
 )
+
+var (
+	ErrMowerNotFound = &NotFoundError{Resource: "Mower"}
+	ErrStoreNotFound = &NotFoundError{Resource: "Store"}
+	ErrUnitNotFound  = &NotFoundError{Resource: "Inventory unit"}
+
+	ErrMalformedID = newError(ErrMalformedQuery, "[Catalog] Id is malformed")
+
+	ErrVersionConflict      = newError(ErrConflict, "[Catalog] Mower has been modified since the expected version")
+	ErrStoresUnavailable    = newError(ErrUnavailable, "[Catalog] Stores are not configured")
+	ErrInventoryUnavailable = newError(ErrUnavailable, "[Catalog] Inventory is not configured")
+	ErrSearchUnavailable    = newError(ErrUnavailable, "[Catalog] Search is not configured")
+	ErrSerialNumberTaken    = newError(ErrConflict, "[Catalog] Serial number is already registered")
+	ErrSerialNumberRequired = newError(ErrValidation, "[Catalog] Serial number is required")
+	ErrMowerHasInventory    = newError(ErrConflict, "[Catalog] Mower still has units in store inventories")
+)
+
+// kindError is a sentinel error of a given kind.
+type kindError struct {
+	kind    error
+	message string
+}
+
+func newError(kind error, message string) error {
+	return &kindError{kind: kind, message: message}
+}
+
+func (e *kindError) Error() string {
+	return e.message
+}
+
+func (e *kindError) Is(target error) bool {
+	return target == e.kind || errors.Is(e.kind, target)
+}
+
+// NotFoundError tells which resource is missing. It matches ErrNotFound as
+// well as the sentinel of its resource, e.g. ErrMowerNotFound.
+type NotFoundError struct {
+	Resource string
+	ID       string
+}
+
+func (e *NotFoundError) Error() string {
+	if e.ID == "" {
+		return fmt.Sprintf("[Catalog] %s not found!", e.Resource)
+	}
+
+	return fmt.Sprintf("[Catalog] %s with id `%v` not found!", e.Resource, e.ID)
+}
+
+func (e *NotFoundError) Is(target error) bool {
+	if target == ErrNotFound {
+		return true
+	}
+
+	t, ok := target.(*NotFoundError)
+
+	return ok && t.Resource == e.Resource && (t.ID == "" || t.ID == e.ID)
+}
+
+func MowerNotFound(id string) error {
+	return &NotFoundError{Resource: ErrMowerNotFound.Resource, ID: id}
+}
+
+func StoreNotFound(id string) error {
+	return &NotFoundError{Resource: ErrStoreNotFound.Resource, ID: id}
+}
+
+func UnitNotFound(id string) error {
+	return &NotFoundError{Resource: ErrUnitNotFound.Resource, ID: id}
+}
+
+// MalformedID reports an id the configured generator could not have made.
+func MalformedID(id string) error {
+	return &kindError{kind: ErrMalformedID, message: fmt.Sprintf("[Catalog] Id `%v` is malformed!", id)}
+}
@@ -1,8 +1,24 @@
 package domain
 
+import "context"
+
+// CatalogRepository stores mowers. Patch only applies when expectedVersion
+// is 0 or equals the stored version, and fails with ErrVersionConflict
+// otherwise.
 type CatalogRepository interface {
-	Find(id string) (*Mower, error)
-	Add(input CreateMowerDTO) (*Mower, error)
-	Patch(id string, input UpdateMowerDTO) (*Mower, error)
-	FindAvailableMowers() ([]*Mower, error)
+	Find(ctx context.Context, id string) (*Mower, error)
+	// FindByName looks the name up regardless of case, soft deleted mowers
+	// included.
+	FindByName(ctx context.Context, name string) (*Mower, error)
+	Add(ctx context.Context, input CreateMowerDTO) (*Mower, error)
+	Patch(ctx context.Context, id string, input UpdateMowerDTO, expectedVersion int64) (*Mower, error)
+	Delete(ctx context.Context, id string) (*Mower, error)
+	Restore(ctx context.Context, id string) (*Mower, error)
+	Purge(ctx context.Context, id string) (*Mower, error)
+	// FindAvailableMowers runs the query, see QueryMowers for the reference
+	// behaviour. StoreID is left to the service, which turns it into IDs.
+	FindAvailableMowers(ctx context.Context, query MowerQuery) ([]*Mower, error)
+	// FindMowerPage pages through the mowers in (createdAt, id) order, see
+	// PageMowers for the reference behaviour.
+	FindMowerPage(ctx context.Context, query PageQuery) (*MowerPage, error)
 }
@@ -11,16 +11,21 @@ type Mower struct {
 	CreatedAt *Timestamp `json:"createdAt,omitempty"`
 	UpdatedAt *Timestamp `json:"updatedAt,omitempty"`
 	DeletedAt *Timestamp `json:"deletedAt,omitempty"`
+	// Version starts at 1 and is incremented by every change of the mower.
+	Version int64 `json:"version"`
 
-	Name string `json:"name"`
+	Name  string     `json:"name"`
+	Specs MowerSpecs `json:"specs"`
 }
 
 type CreateMowerDTO struct {
-	Name string `json:"name"`
+	Name  string     `json:"name"`
+	Specs MowerSpecs `json:"specs"`
 }
 
 type UpdateMowerDTO struct {
-	Name string `json:"name,omitempty"`
+	Name  string           `json:"name,omitempty"`
+	Specs *MowerSpecsPatch `json:"specs,omitempty"`
 }
 
 func (m Mower) String() string {
@@ -39,7 +44,7 @@ func (t *Timestamp) UnmarshalJSON(b []byte) error {
 	if err != nil {
 		return err
 	}
-	*t = Timestamp(time.Unix(int64(ts), 0))
+	*t = Timestamp(time.Unix(int64(ts), 0).UTC())
 	return nil
 }
 
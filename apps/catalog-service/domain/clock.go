@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// Clock tells repositories what time it is when they stamp CreatedAt,
+// UpdatedAt and DeletedAt.
+type Clock interface {
+	Now() time.Time
+}
+
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// NewTimestamp returns t in UTC with microsecond precision, the resolution
+// every repository is able to store.
+func NewTimestamp(t time.Time) *Timestamp {
+	ts := Timestamp(t.UTC().Truncate(time.Microsecond))
+	return &ts
+}
+
+// Stamp returns the current time of clock as a stored timestamp.
+func Stamp(clock Clock) *Timestamp {
+	return NewTimestamp(clock.Now())
+}
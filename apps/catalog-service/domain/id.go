@@ -0,0 +1,8 @@
+package domain
+
+// IDGenerator hands out identifiers for new entities and recognises the ones
+// it could have produced, so that malformed ids can be rejected early.
+type IDGenerator interface {
+	NewID() string
+	Valid(id string) bool
+}
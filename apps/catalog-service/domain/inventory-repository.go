@@ -0,0 +1,20 @@
+package domain
+
+import "context"
+
+// InventoryRepository stores inventory units. Retired units keep their serial
+// number, which can never be registered again, but are left out of every
+// listing; their model can no longer be purged. Find, Retire and Move return
+// nil without error for unknown ids.
+type InventoryRepository interface {
+	Find(ctx context.Context, id string) (*InventoryUnit, error)
+	// Add fails with ErrSerialNumberTaken when the serial number is in use.
+	Add(ctx context.Context, input RegisterUnitDTO) (*InventoryUnit, error)
+	Retire(ctx context.Context, id string) (*InventoryUnit, error)
+	Move(ctx context.Context, id string, storeID string) (*InventoryUnit, error)
+	FindByStore(ctx context.Context, storeID string) ([]*InventoryUnit, error)
+	FindByModel(ctx context.Context, modelID string) ([]*InventoryUnit, error)
+	// FindStockedModelIDs returns the ids of the mower models the store has
+	// at least one live unit of.
+	FindStockedModelIDs(ctx context.Context, storeID string) ([]string, error)
+}
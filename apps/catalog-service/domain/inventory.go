@@ -0,0 +1,26 @@
+package domain
+
+import "fmt"
+
+// InventoryUnit is one physical mower, identified by its serial number, of a
+// catalog model held by a store. It is what customers actually book.
+type InventoryUnit struct {
+	ID        string     `json:"id"`
+	CreatedAt *Timestamp `json:"createdAt,omitempty"`
+	UpdatedAt *Timestamp `json:"updatedAt,omitempty"`
+	DeletedAt *Timestamp `json:"deletedAt,omitempty"`
+
+	SerialNumber string `json:"ns"`
+	ModelID      string `json:"model"`
+	StoreID      string `json:"store"`
+}
+
+type RegisterUnitDTO struct {
+	SerialNumber string `json:"ns"`
+	ModelID      string `json:"model"`
+	StoreID      string `json:"store"`
+}
+
+func (u InventoryUnit) String() string {
+	return fmt.Sprintf("unit %s of model #%v at store #%v", u.SerialNumber, u.ModelID, u.StoreID)
+}
@@ -0,0 +1,321 @@
+package domain
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 200
+)
+
+// Cursor points between two mowers of the ordering of a query, by the
+// fields of its sort then (createdAt, id): a page starts right after it, or
+// ends right before it when Before is set.
+type Cursor struct {
+	// Keys are the values of the sort fields of the mower the cursor was
+	// made from, in the order of the sort.
+	Keys      []SortKey
+	CreatedAt time.Time
+	ID        string
+	Before    bool
+}
+
+// SortKey is the value of a sort field, typed like the values of filters.
+type SortKey struct {
+	Field string
+	Desc  bool
+	Value interface{}
+}
+
+// CursorAfter and CursorBefore point right after and right before mower in
+// the ordering of sort.
+func CursorAfter(mower *Mower, sort []SortField) *Cursor {
+	return &Cursor{Keys: sortKeys(mower, sort), CreatedAt: createdAt(mower), ID: mower.ID}
+}
+
+func CursorBefore(mower *Mower, sort []SortField) *Cursor {
+	return &Cursor{Keys: sortKeys(mower, sort), CreatedAt: createdAt(mower), ID: mower.ID, Before: true}
+}
+
+func sortKeys(mower *Mower, sort []SortField) []SortKey {
+	keys := make([]SortKey, len(sort))
+
+	for i, s := range sort {
+		keys[i] = SortKey{Field: s.Field, Desc: s.Desc, Value: mowerFields[s.Field].value(mower)}
+	}
+
+	return keys
+}
+
+// Encode turns the cursor into the opaque string handed out to clients.
+func (c Cursor) Encode() string {
+	direction := "a"
+
+	if c.Before {
+		direction = "b"
+	}
+
+	raw := fmt.Sprintf("%s|%s|%s|%s", direction, c.CreatedAt.UTC().Format(time.RFC3339Nano), encodeKeys(c.Keys), c.ID)
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// encodeKeys writes the keys as `field=value` pairs, in order, with a `-`
+// before the fields sorted in descending order.
+func encodeKeys(keys []SortKey) string {
+	pairs := make([]string, len(keys))
+
+	for i, key := range keys {
+		field := key.Field
+
+		if key.Desc {
+			field = "-" + field
+		}
+
+		pairs[i] = field + "=" + url.QueryEscape(formatKey(key.Value))
+	}
+
+	return strings.Join(pairs, "&")
+}
+
+func formatKey(value interface{}) string {
+	switch value := value.(type) {
+	case float64:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	case time.Time:
+		return value.UTC().Format(time.RFC3339Nano)
+	}
+
+	return fmt.Sprint(value)
+}
+
+// DecodeCursor reads a cursor handed out by Encode.
+func DecodeCursor(s string) (*Cursor, error) {
+	malformed := InvalidQuery("cursor is malformed")
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+
+	if err != nil {
+		return nil, malformed
+	}
+
+	parts := strings.SplitN(string(raw), "|", 4)
+
+	if len(parts) != 4 || (parts[0] != "a" && parts[0] != "b") {
+		return nil, malformed
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[1])
+
+	if err != nil {
+		return nil, malformed
+	}
+
+	cursor := &Cursor{CreatedAt: createdAt, ID: parts[3], Before: parts[0] == "b"}
+
+	if parts[2] == "" {
+		return cursor, nil
+	}
+
+	for _, pair := range strings.Split(parts[2], "&") {
+		field, escaped, _ := strings.Cut(pair, "=")
+		desc := strings.HasPrefix(field, "-")
+		field = strings.TrimPrefix(field, "-")
+		f, known := mowerFields[field]
+		value, err := url.QueryUnescape(escaped)
+
+		if !known || !f.sortable || err != nil {
+			return nil, malformed
+		}
+
+		key := SortKey{Field: field, Desc: desc, Value: value}
+
+		switch f.typ {
+		case numberField:
+			key.Value, err = strconv.ParseFloat(value, 64)
+		case timeField:
+			key.Value, err = time.Parse(time.RFC3339Nano, value)
+		}
+
+		if err != nil {
+			return nil, malformed
+		}
+
+		cursor.Keys = append(cursor.Keys, key)
+	}
+
+	return cursor, nil
+}
+
+// PageQuery asks for at most Limit of the mowers of the query next to
+// Cursor, or from the start when Cursor is nil.
+type PageQuery struct {
+	MowerQuery
+	Limit  int
+	Cursor *Cursor
+	// CountTotal asks for the number of mowers across every page.
+	CountTotal bool
+}
+
+// MowerPage lists mowers in the order of the sort of the query, then
+// (createdAt, id). Next and Prev are nil on the last and first page.
+type MowerPage struct {
+	Mowers []*Mower
+	Next   *Cursor
+	Prev   *Cursor
+	Total  *int
+}
+
+// Validate applies the default limit and rejects limits out of bounds.
+func (q *PageQuery) Validate() error {
+	if q.Limit == 0 {
+		q.Limit = DefaultPageLimit
+	}
+
+	if q.Limit < 1 || q.Limit > MaxPageLimit {
+		return InvalidQuery(fmt.Sprintf("limit must be between 1 and %d", MaxPageLimit))
+	}
+
+	if q.Cursor != nil && !q.Cursor.follows(q.Sort) {
+		return InvalidQuery("cursor does not match the sort")
+	}
+
+	return nil
+}
+
+// follows reports whether the cursor was made for a query sorted by sort.
+func (c Cursor) follows(sort []SortField) bool {
+	if len(c.Keys) != len(sort) {
+		return false
+	}
+
+	for i, s := range sort {
+		if c.Keys[i].Field != s.Field || c.Keys[i].Desc != s.Desc {
+			return false
+		}
+	}
+
+	return true
+}
+
+// InvalidQuery reports a listing request that cannot be answered as asked.
+func InvalidQuery(reason string) error {
+	return &kindError{kind: ErrMalformedQuery, message: "[Catalog] Query is invalid: " + reason}
+}
+
+// NewMowerPage builds a page out of the limit+1 mowers fetched past the
+// cursor, in the direction of the cursor, so that both repositories share
+// the same paging rules. The cursors of the page follow the sort of query.
+func NewMowerPage(fetched []*Mower, query PageQuery) *MowerPage {
+	more := len(fetched) > query.Limit
+
+	if more {
+		fetched = fetched[:query.Limit]
+	}
+
+	page := &MowerPage{Mowers: fetched}
+
+	if query.Cursor != nil && query.Cursor.Before {
+		// Fetched backwards: put them back in order.
+		for i, j := 0, len(fetched)-1; i < j; i, j = i+1, j-1 {
+			fetched[i], fetched[j] = fetched[j], fetched[i]
+		}
+	}
+
+	if len(fetched) == 0 {
+		return page
+	}
+
+	first, last := fetched[0], fetched[len(fetched)-1]
+	backwards := query.Cursor != nil && query.Cursor.Before
+
+	if (backwards && more) || (!backwards && query.Cursor != nil) {
+		page.Prev = CursorBefore(first, query.Sort)
+	}
+
+	if (!backwards && more) || backwards {
+		page.Next = CursorAfter(last, query.Sort)
+	}
+
+	return page
+}
+
+// PageMowers pages through mowers in memory with the same rules as the SQL
+// keyset queries: keep the mowers of the query in its order, then take the
+// limit+1 mowers past the cursor.
+func PageMowers(mowers []*Mower, query PageQuery) *MowerPage {
+	sorted := QueryMowers(mowers, query.MowerQuery)
+
+	total := len(sorted)
+	fetched := []*Mower{}
+
+	if query.Cursor != nil && query.Cursor.Before {
+		for i := len(sorted) - 1; i >= 0 && len(fetched) <= query.Limit; i-- {
+			if query.Cursor.compare(sorted[i], query.Sort) > 0 {
+				fetched = append(fetched, sorted[i])
+			}
+		}
+	} else {
+		for i := 0; i < len(sorted) && len(fetched) <= query.Limit; i++ {
+			if query.Cursor == nil || query.Cursor.compare(sorted[i], query.Sort) < 0 {
+				fetched = append(fetched, sorted[i])
+			}
+		}
+	}
+
+	page := NewMowerPage(fetched, query)
+
+	if query.CountTotal {
+		page.Total = &total
+	}
+
+	return page
+}
+
+// compare tells whether the cursor comes before mower, when negative, or
+// after it, when positive, in the ordering of sort.
+func (c Cursor) compare(mower *Mower, sort []SortField) int {
+	for i, s := range sort {
+		order := compareValues(c.Keys[i].Value, mowerFields[s.Field].value(mower))
+
+		if s.Desc {
+			order = -order
+		}
+
+		if order != 0 {
+			return order
+		}
+	}
+
+	switch t := createdAt(mower); {
+	case c.CreatedAt.Before(t):
+		return -1
+	case c.CreatedAt.After(t):
+		return 1
+	}
+
+	return strings.Compare(c.ID, mower.ID)
+}
+
+func mowerLess(a, b *Mower) bool {
+	ta, tb := createdAt(a), createdAt(b)
+
+	if !ta.Equal(tb) {
+		return ta.Before(tb)
+	}
+
+	return a.ID < b.ID
+}
+
+func createdAt(mower *Mower) time.Time {
+	if mower.CreatedAt == nil {
+		return time.Time{}
+	}
+
+	return time.Time(*mower.CreatedAt)
+}
@@ -0,0 +1,397 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Operator compares a mower field with the value of a filter.
+type Operator string
+
+const (
+	OpEq  Operator = "eq"
+	OpNe  Operator = "ne"
+	OpGt  Operator = "gt"
+	OpGte Operator = "gte"
+	OpLt  Operator = "lt"
+	OpLte Operator = "lte"
+)
+
+var (
+	equalityOperators = []Operator{OpEq, OpNe}
+	orderingOperators = []Operator{OpEq, OpNe, OpGt, OpGte, OpLt, OpLte}
+)
+
+// Availability tells which mowers a query keeps depending on soft deletion.
+type Availability int
+
+const (
+	// OnlyAvailable keeps the mowers that are not deleted, the default.
+	OnlyAvailable Availability = iota
+	OnlyDeleted
+	AnyAvailability
+)
+
+// MowerQuery selects and orders mowers. Mowers always come in creation order
+// once sorted by the fields of Sort.
+type MowerQuery struct {
+	Filters      []MowerFilter
+	Sort         []SortField
+	Availability Availability
+	// StoreID keeps the models the store has live units of. Repositories
+	// know nothing of inventories: they honour IDs instead, which the
+	// service fills from the stock of the store.
+	StoreID string
+	// IDs keeps the mowers with one of these ids when not nil.
+	IDs []string
+}
+
+// MowerFilter keeps the mowers whose Field compares to Value with Op. Value
+// is a string, a float64, a bool or a time.Time depending on the field.
+type MowerFilter struct {
+	Field string
+	Op    Operator
+	Value interface{}
+}
+
+// SortField orders mowers by Field, in descending order when Desc is set.
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+type fieldType int
+
+const (
+	stringField fieldType = iota
+	numberField
+	boolField
+	timeField
+)
+
+// mowerField describes a field mowers can be filtered on. Unknown fields are
+// specs where zero means not known, and never match a filter.
+type mowerField struct {
+	typ       fieldType
+	operators []Operator
+	sortable  bool
+	unknown   bool
+	value     func(mower *Mower) interface{}
+}
+
+var mowerFields = map[string]mowerField{
+	"name": {stringField, equalityOperators, true, false, func(m *Mower) interface{} {
+		return m.Name
+	}},
+	"powerSource": {stringField, equalityOperators, true, false, func(m *Mower) interface{} {
+		return string(m.Specs.PowerSource)
+	}},
+	"cuttingWidthCm": {numberField, orderingOperators, true, true, func(m *Mower) interface{} {
+		return float64(m.Specs.CuttingWidthCm)
+	}},
+	"cuttingHeightMinMm": {numberField, orderingOperators, true, true, func(m *Mower) interface{} {
+		return float64(m.Specs.CuttingHeightMinMm)
+	}},
+	"cuttingHeightMaxMm": {numberField, orderingOperators, true, true, func(m *Mower) interface{} {
+		return float64(m.Specs.CuttingHeightMaxMm)
+	}},
+	"grassBagLiters": {numberField, orderingOperators, true, true, func(m *Mower) interface{} {
+		return float64(m.Specs.GrassBagLiters)
+	}},
+	"weightKg": {numberField, orderingOperators, true, true, func(m *Mower) interface{} {
+		return m.Specs.WeightKg
+	}},
+	"recommendedAreaM2": {numberField, orderingOperators, true, true, func(m *Mower) interface{} {
+		return float64(m.Specs.RecommendedAreaM2)
+	}},
+	"selfPropelled": {boolField, equalityOperators, false, false, func(m *Mower) interface{} {
+		return m.Specs.SelfPropelled
+	}},
+	"createdAt": {timeField, orderingOperators, true, false, func(m *Mower) interface{} {
+		return createdAt(m)
+	}},
+}
+
+// QueryError reports a query parameter that is not understood, along with
+// the values that would be.
+type QueryError struct {
+	Param   string
+	Reason  string
+	Allowed []string
+}
+
+func (e *QueryError) Error() string {
+	message := fmt.Sprintf("[Catalog] Query is invalid: `%s` %s", e.Param, e.Reason)
+
+	if len(e.Allowed) > 0 {
+		message += fmt.Sprintf(" (allowed: %s)", strings.Join(e.Allowed, ", "))
+	}
+
+	return message
+}
+
+func (e *QueryError) Is(target error) bool {
+	return target == ErrMalformedQuery
+}
+
+// MowerFilterFields and MowerSortFields list, in alphabetical order, the
+// fields mowers can be filtered and sorted on.
+func MowerFilterFields() []string {
+	return fieldNames(func(mowerField) bool { return true })
+}
+
+func MowerSortFields() []string {
+	return fieldNames(func(f mowerField) bool { return f.sortable })
+}
+
+func fieldNames(keep func(mowerField) bool) []string {
+	names := []string{}
+
+	for name, field := range mowerFields {
+		if keep(field) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// NewMowerFilter parses raw into the type of field. Unknown fields,
+// operators the field does not support and values of the wrong type are
+// reported as a *QueryError.
+func NewMowerFilter(field string, op Operator, raw string) (MowerFilter, error) {
+	f, ok := mowerFields[field]
+
+	if !ok {
+		return MowerFilter{}, &QueryError{Param: field, Reason: "is not a known field", Allowed: MowerFilterFields()}
+	}
+
+	if !hasOperator(f.operators, op) {
+		allowed := []string{}
+
+		for _, operator := range f.operators {
+			allowed = append(allowed, string(operator))
+		}
+
+		return MowerFilter{}, &QueryError{Param: fmt.Sprintf("%s[%s]", field, op), Reason: "is not a known operator", Allowed: allowed}
+	}
+
+	value, err := parseValue(field, f.typ, raw)
+
+	if err != nil {
+		return MowerFilter{}, err
+	}
+
+	return MowerFilter{Field: field, Op: op, Value: value}, nil
+}
+
+func hasOperator(operators []Operator, op Operator) bool {
+	for _, operator := range operators {
+		if operator == op {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseValue(field string, typ fieldType, raw string) (interface{}, error) {
+	switch typ {
+	case numberField:
+		n, err := strconv.ParseFloat(raw, 64)
+
+		if err != nil {
+			return nil, &QueryError{Param: field, Reason: "must be a number"}
+		}
+
+		return n, nil
+	case boolField:
+		b, err := strconv.ParseBool(raw)
+
+		if err != nil {
+			return nil, &QueryError{Param: field, Reason: "must be a boolean", Allowed: []string{"true", "false"}}
+		}
+
+		return b, nil
+	case timeField:
+		// Timestamps are served as unix seconds, accept them as well as
+		// RFC 3339 dates.
+		if seconds, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			return time.Unix(seconds, 0).UTC(), nil
+		}
+
+		t, err := time.Parse(time.RFC3339, raw)
+
+		if err != nil {
+			return nil, &QueryError{Param: field, Reason: "must be unix seconds or an RFC 3339 date"}
+		}
+
+		return t, nil
+	}
+
+	if field == "powerSource" && !PowerSource(raw).Valid() {
+		allowed := []string{}
+
+		for _, source := range PowerSources {
+			allowed = append(allowed, string(source))
+		}
+
+		return nil, &QueryError{Param: field, Reason: "is not a known power source", Allowed: allowed}
+	}
+
+	return raw, nil
+}
+
+// ParseSort reads a comma separated list of fields, each prefixed with `-`
+// to sort in descending order, e.g. `-cuttingWidthCm,name`.
+func ParseSort(raw string) ([]SortField, error) {
+	fields := []SortField{}
+
+	for _, name := range strings.Split(raw, ",") {
+		field := SortField{Field: strings.TrimPrefix(name, "-"), Desc: strings.HasPrefix(name, "-")}
+
+		if f, ok := mowerFields[field.Field]; !ok || !f.sortable {
+			return nil, &QueryError{Param: "sort", Reason: fmt.Sprintf("cannot use `%s`", name), Allowed: MowerSortFields()}
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// SkipsUnknown reports whether the field of the filter is a spec where zero
+// means not known: such zero specs never match the filter.
+func (f MowerFilter) SkipsUnknown() bool {
+	return mowerFields[f.Field].unknown
+}
+
+// Match reports whether mower passes every criterion of the query.
+func (q MowerQuery) Match(mower *Mower) bool {
+	switch {
+	case q.Availability == OnlyAvailable && mower.DeletedAt != nil,
+		q.Availability == OnlyDeleted && mower.DeletedAt == nil:
+		return false
+	}
+
+	if q.IDs != nil && !containsID(q.IDs, mower.ID) {
+		return false
+	}
+
+	for _, filter := range q.Filters {
+		if !filter.match(mower) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsID(ids []string, id string) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f MowerFilter) match(mower *Mower) bool {
+	value := mowerFields[f.Field].value(mower)
+
+	if f.SkipsUnknown() && value == float64(0) {
+		return false
+	}
+
+	c := compareValues(value, f.Value)
+
+	switch f.Op {
+	case OpEq:
+		return c == 0
+	case OpNe:
+		return c != 0
+	case OpGt:
+		return c > 0
+	case OpGte:
+		return c >= 0
+	case OpLt:
+		return c < 0
+	case OpLte:
+		return c <= 0
+	}
+
+	return false
+}
+
+func compareValues(a, b interface{}) int {
+	switch a := a.(type) {
+	case string:
+		return strings.Compare(a, b.(string))
+	case float64:
+		switch b := b.(float64); {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+	case bool:
+		if a != b.(bool) {
+			if a {
+				return 1
+			}
+
+			return -1
+		}
+	case time.Time:
+		switch b := b.(time.Time); {
+		case a.Before(b):
+			return -1
+		case a.After(b):
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// QueryMowers runs the query in memory. It is the reference behaviour of
+// FindAvailableMowers.
+func QueryMowers(mowers []*Mower, query MowerQuery) []*Mower {
+	found := []*Mower{}
+
+	for _, mower := range mowers {
+		if query.Match(mower) {
+			found = append(found, mower)
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		return query.less(found[i], found[j])
+	})
+
+	return found
+}
+
+// less orders mowers by the fields of Sort, then in creation order.
+func (q MowerQuery) less(a, b *Mower) bool {
+	for _, s := range q.Sort {
+		value := mowerFields[s.Field].value
+		c := compareValues(value(a), value(b))
+
+		if s.Desc {
+			c = -c
+		}
+
+		if c != 0 {
+			return c < 0
+		}
+	}
+
+	return mowerLess(a, b)
+}
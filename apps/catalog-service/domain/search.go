@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	DefaultSearchLimit = 10
+	MaxSearchLimit     = 50
+)
+
+// SearchIndex finds mowers out of what customers type. It only knows live
+// mowers: the service indexes mowers as they are created, updated or
+// restored and removes them once deleted. Concurrent changes of a mower may
+// reach the index out of order: a change older than the version it already
+// has of the mower, removals included, is ignored. Indexes backed by the
+// catalog store itself may ignore Index and Remove.
+type SearchIndex interface {
+	Index(ctx context.Context, mower *Mower) error
+	// Remove forgets the mower id as of version, the version of its removal.
+	Remove(ctx context.Context, id string, version int64) error
+	// Search returns at most limit mowers matching every word of text, best
+	// matches first.
+	Search(ctx context.Context, text string, limit int) ([]SearchHit, error)
+	// Suggest returns at most limit mower names starting with prefix.
+	Suggest(ctx context.Context, prefix string, limit int) ([]string, error)
+}
+
+type SearchHit struct {
+	Mower *Mower  `json:"mower"`
+	Score float64 `json:"score"`
+}
+
+// ValidateSearch rejects blank texts and returns the limit to use,
+// DefaultSearchLimit when none is given.
+func ValidateSearch(param, text string, limit int) (int, error) {
+	if strings.TrimSpace(text) == "" {
+		return 0, &QueryError{Param: param, Reason: "is required"}
+	}
+
+	if limit == 0 {
+		return DefaultSearchLimit, nil
+	}
+
+	if limit < 1 || limit > MaxSearchLimit {
+		return 0, InvalidQuery(fmt.Sprintf("limit must be between 1 and %d", MaxSearchLimit))
+	}
+
+	return limit, nil
+}
+
+// SearchWords splits text into lower case words of letters and digits.
+// Compound words such as `M-480` are given joined, `m480`, followed by their
+// parts, `m` and `480`, so that `M-4`, `m4` and `M 480` all find them.
+func SearchWords(text string) [][]string {
+	words := [][]string{}
+
+	for _, field := range strings.Fields(strings.ToLower(text)) {
+		parts := strings.FieldsFunc(field, func(r rune) bool {
+			return !isWordRune(r)
+		})
+
+		switch len(parts) {
+		case 0:
+			continue
+		case 1:
+			words = append(words, parts)
+		default:
+			words = append(words, append([]string{strings.Join(parts, "")}, parts...))
+		}
+	}
+
+	return words
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
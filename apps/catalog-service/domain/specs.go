@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+type PowerSource string
+
+const (
+	PowerSourcePetrol   PowerSource = "petrol"
+	PowerSourceElectric PowerSource = "electric"
+	PowerSourceBattery  PowerSource = "battery"
+	PowerSourceRobotic  PowerSource = "robotic"
+)
+
+var PowerSources = []PowerSource{PowerSourcePetrol, PowerSourceElectric, PowerSourceBattery, PowerSourceRobotic}
+
+// Bounds of the mower specs. A zero spec means it is not known.
+const (
+	MinCuttingWidthCm    = 20
+	MaxCuttingWidthCm    = 150
+	MinCuttingHeightMm   = 10
+	MaxCuttingHeightMm   = 120
+	MaxGrassBagLiters    = 150
+	MaxWeightKg          = 500
+	MaxRecommendedAreaM2 = 100000
+	MaxDescriptionLength = 2000
+)
+
+// MowerSpecs describes what a mower model can do, so that customers can pick
+// the right machine.
+type MowerSpecs struct {
+	PowerSource        PowerSource `json:"powerSource,omitempty"`
+	CuttingWidthCm     int         `json:"cuttingWidthCm,omitempty"`
+	CuttingHeightMinMm int         `json:"cuttingHeightMinMm,omitempty"`
+	CuttingHeightMaxMm int         `json:"cuttingHeightMaxMm,omitempty"`
+	GrassBagLiters     int         `json:"grassBagLiters,omitempty"`
+	WeightKg           float64     `json:"weightKg,omitempty"`
+	SelfPropelled      bool        `json:"selfPropelled"`
+	RecommendedAreaM2  int         `json:"recommendedAreaM2,omitempty"`
+	Description        string      `json:"description,omitempty"`
+}
+
+// MowerSpecsPatch lists the specs to change, nil fields are left unchanged.
+type MowerSpecsPatch struct {
+	PowerSource        *PowerSource `json:"powerSource,omitempty"`
+	CuttingWidthCm     *int         `json:"cuttingWidthCm,omitempty"`
+	CuttingHeightMinMm *int         `json:"cuttingHeightMinMm,omitempty"`
+	CuttingHeightMaxMm *int         `json:"cuttingHeightMaxMm,omitempty"`
+	GrassBagLiters     *int         `json:"grassBagLiters,omitempty"`
+	WeightKg           *float64     `json:"weightKg,omitempty"`
+	SelfPropelled      *bool        `json:"selfPropelled,omitempty"`
+	RecommendedAreaM2  *int         `json:"recommendedAreaM2,omitempty"`
+	Description        *string      `json:"description,omitempty"`
+}
+
+// Apply returns the specs with the fields set in patch replaced.
+func (s MowerSpecs) Apply(patch MowerSpecsPatch) MowerSpecs {
+	if patch.PowerSource != nil {
+		s.PowerSource = *patch.PowerSource
+	}
+	if patch.CuttingWidthCm != nil {
+		s.CuttingWidthCm = *patch.CuttingWidthCm
+	}
+	if patch.CuttingHeightMinMm != nil {
+		s.CuttingHeightMinMm = *patch.CuttingHeightMinMm
+	}
+	if patch.CuttingHeightMaxMm != nil {
+		s.CuttingHeightMaxMm = *patch.CuttingHeightMaxMm
+	}
+	if patch.GrassBagLiters != nil {
+		s.GrassBagLiters = *patch.GrassBagLiters
+	}
+	if patch.WeightKg != nil {
+		s.WeightKg = *patch.WeightKg
+	}
+	if patch.SelfPropelled != nil {
+		s.SelfPropelled = *patch.SelfPropelled
+	}
+	if patch.RecommendedAreaM2 != nil {
+		s.RecommendedAreaM2 = *patch.RecommendedAreaM2
+	}
+	if patch.Description != nil {
+		s.Description = *patch.Description
+	}
+
+	return s
+}
+
+// Validate checks every known spec against its bounds.
+func (s MowerSpecs) Validate() error {
+	v := &ValidationError{Resource: "mower"}
+
+	s.validate(v)
+
+	return v.OrNil()
+}
+
+func (s MowerSpecs) validate(v *ValidationError) {
+	if s.PowerSource != "" && !s.PowerSource.Valid() {
+		v.Add("specs.powerSource", fmt.Sprintf("must be one of %v", PowerSources))
+	}
+
+	v.checkRange("specs.cuttingWidthCm", s.CuttingWidthCm, MinCuttingWidthCm, MaxCuttingWidthCm)
+	v.checkRange("specs.cuttingHeightMinMm", s.CuttingHeightMinMm, MinCuttingHeightMm, MaxCuttingHeightMm)
+	v.checkRange("specs.cuttingHeightMaxMm", s.CuttingHeightMaxMm, MinCuttingHeightMm, MaxCuttingHeightMm)
+
+	if s.CuttingHeightMinMm != 0 && s.CuttingHeightMaxMm != 0 && s.CuttingHeightMinMm > s.CuttingHeightMaxMm {
+		v.Add("specs.cuttingHeightMaxMm", "must not be lower than specs.cuttingHeightMinMm")
+	}
+
+	v.checkRange("specs.grassBagLiters", s.GrassBagLiters, 0, MaxGrassBagLiters)
+
+	if s.WeightKg < 0 || s.WeightKg > MaxWeightKg {
+		v.Add("specs.weightKg", fmt.Sprintf("must be between 0 and %d", MaxWeightKg))
+	}
+
+	v.checkRange("specs.recommendedAreaM2", s.RecommendedAreaM2, 0, MaxRecommendedAreaM2)
+
+	if utf8.RuneCountInString(s.Description) > MaxDescriptionLength {
+		v.Add("specs.description", fmt.Sprintf("must not exceed %d characters", MaxDescriptionLength))
+	}
+}
+
+func (p PowerSource) Valid() bool {
+	for _, source := range PowerSources {
+		if p == source {
+			return true
+		}
+	}
+
+	return false
+}
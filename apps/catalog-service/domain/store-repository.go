@@ -0,0 +1,11 @@
+package domain
+
+import "context"
+
+// StoreRepository stores the stores. Find and Patch return nil without error
+// when the store does not exist.
+type StoreRepository interface {
+	Find(ctx context.Context, id string) (*Store, error)
+	Add(ctx context.Context, input CreateStoreDTO) (*Store, error)
+	Patch(ctx context.Context, id string, input UpdateStoreDTO) (*Store, error)
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "fmt"
+
+// Store is a shop renting mowers out to customers.
+type Store struct {
+	ID        string     `json:"id"`
+	CreatedAt *Timestamp `json:"createdAt,omitempty"`
+	UpdatedAt *Timestamp `json:"updatedAt,omitempty"`
+	DeletedAt *Timestamp `json:"deletedAt,omitempty"`
+
+	Name string `json:"name"`
+}
+
+type CreateStoreDTO struct {
+	Name string `json:"name"`
+}
+
+type UpdateStoreDTO struct {
+	Name string `json:"name,omitempty"`
+}
+
+// Validate returns a *ValidationError when the name is blank or too long.
+func (input CreateStoreDTO) Validate() error {
+	errs := &ValidationError{Resource: "store"}
+
+	errs.checkName(input.Name)
+
+	return errs.OrNil()
+}
+
+// Validate checks the name when it is given, an empty one leaving it as is.
+func (input UpdateStoreDTO) Validate() error {
+	errs := &ValidationError{Resource: "store"}
+
+	if input.Name != "" {
+		errs.checkName(input.Name)
+	}
+
+	return errs.OrNil()
+}
+
+func (s Store) String() string {
+	return fmt.Sprintf("store %s (#%v)", s.Name, s.ID)
+}
@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const MaxNameLength = 100
+
+// FieldError tells which field is invalid and why.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s %s", e.Field, e.Message)
+}
+
+// ValidationError lists every invalid field of an input, in the order they
+// were checked. Resource names what the input describes, "mower" or
+// "store", in the message; it reads "input" when unset.
+type ValidationError struct {
+	Resource string       `json:"-"`
+	Fields   []FieldError `json:"fields"`
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+
+	for i, field := range e.Fields {
+		messages[i] = field.Error()
+	}
+
+	resource := e.Resource
+
+	if resource == "" {
+		resource = "input"
+	}
+
+	return "[Catalog] Invalid " + resource + ": " + strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+func (e *ValidationError) Add(field, message string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: message})
+}
+
+// OrNil returns e when a field failed and nil otherwise, so that an empty
+// ValidationError never ends up in a non-nil error interface.
+func (e *ValidationError) OrNil() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+
+	return e
+}
+
+// checkRange accepts 0, meaning unknown, or a value within [min, max].
+func (e *ValidationError) checkRange(field string, value, min, max int) {
+	if value != 0 && (value < min || value > max) {
+		e.Add(field, fmt.Sprintf("must be between %d and %d", min, max))
+	}
+}
+
+func (e *ValidationError) checkName(name string) {
+	if strings.TrimSpace(name) == "" {
+		e.Add("name", "is required")
+	} else if utf8.RuneCountInString(name) > MaxNameLength {
+		e.Add("name", fmt.Sprintf("must not exceed %d characters", MaxNameLength))
+	}
+}
+
+const nameTakenMessage = "is already used by another mower"
+
+// NameTaken is the error of the repositories when a name is taken by the
+// time they write it, after the validator found it free.
+func NameTaken() error {
+	errs := &ValidationError{Resource: "mower"}
+	errs.Add("name", nameTakenMessage)
+
+	return errs
+}
+
+// MowerValidator checks mower inputs before they reach the repository. Names
+// are unique across the catalog, soft deleted mowers included, regardless of
+// case; the repositories enforce it again as they write, since another
+// request may take the name in between.
+type MowerValidator struct {
+	repo CatalogRepository
+}
+
+func NewMowerValidator(repo CatalogRepository) *MowerValidator {
+	return &MowerValidator{repo: repo}
+}
+
+// ValidateCreate returns a *ValidationError listing every invalid field of
+// input, or the repository error preventing the name check.
+func (v *MowerValidator) ValidateCreate(ctx context.Context, input CreateMowerDTO) error {
+	errs := &ValidationError{Resource: "mower"}
+
+	errs.checkName(input.Name)
+
+	if err := v.checkNameTaken(ctx, errs, input.Name, ""); err != nil {
+		return err
+	}
+
+	input.Specs.validate(errs)
+
+	return errs.OrNil()
+}
+
+// ValidateUpdate checks input as a partial update of current: only the given
+// fields are checked, specs once merged with the current ones.
+func (v *MowerValidator) ValidateUpdate(ctx context.Context, current Mower, input UpdateMowerDTO) error {
+	errs := &ValidationError{Resource: "mower"}
+
+	if input.Name != "" {
+		errs.checkName(input.Name)
+
+		if err := v.checkNameTaken(ctx, errs, input.Name, current.ID); err != nil {
+			return err
+		}
+	}
+
+	if input.Specs != nil {
+		current.Specs.Apply(*input.Specs).validate(errs)
+	}
+
+	return errs.OrNil()
+}
+
+func (v *MowerValidator) checkNameTaken(ctx context.Context, errs *ValidationError, name string, exceptID string) error {
+	if strings.TrimSpace(name) == "" {
+		return nil
+	}
+
+	existing, err := v.repo.FindByName(ctx, name)
+
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && existing.ID != exceptID {
+		errs.Add("name", nameTakenMessage)
+	}
+
+	return nil
+}
@@ -0,0 +1,381 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"jrobic/lawn-mower/catalog-service/infra/idgen"
+	"jrobic/lawn-mower/catalog-service/infra/repository"
+	"net"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	BackendMemory   = "memory"
+	BackendPostgres = "postgres"
+	BackendFile     = "file"
+
+	LogLevelDebug = "debug"
+	LogLevelInfo  = "info"
+)
+
+// redacted replaces the secrets printed by --print-config.
+const redacted = "REDACTED"
+
+// Config is everything the catalog service can be configured with. Load
+// builds it from, by increasing precedence, Default, a YAML file, CATALOG_*
+// environment variables and command line flags.
+type Config struct {
+	HTTP       HTTPConfig       `yaml:"http"`
+	GRPC       GRPCConfig       `yaml:"grpc"`
+	Repository RepositoryConfig `yaml:"repository"`
+	Cache      CacheConfig      `yaml:"cache"`
+	// AdminToken enables the admin operations, see restcontroller.WithAdminToken.
+	AdminToken      string        `yaml:"adminToken"`
+	RequireIfMatch  bool          `yaml:"requireIfMatch"`
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout"`
+	// DrainDelay is how long the HTTP server answers /readyz with 503 before
+	// it stops, see restcontroller.WithDrainDelay. It counts in
+	// ShutdownTimeout.
+	DrainDelay          time.Duration `yaml:"drainDelay"`
+	HealthCheckInterval time.Duration `yaml:"healthCheckInterval"`
+	// LogLevel is info, or debug to also log every HTTP request.
+	LogLevel string `yaml:"logLevel"`
+}
+
+type HTTPConfig struct {
+	Addr         string        `yaml:"addr"`
+	ReadTimeout  time.Duration `yaml:"readTimeout"`
+	WriteTimeout time.Duration `yaml:"writeTimeout"`
+	IdleTimeout  time.Duration `yaml:"idleTimeout"`
+	// RequestTimeout and QueryTimeout are the deadlines of the requests, see
+	// restcontroller.WithRequestTimeouts.
+	RequestTimeout time.Duration `yaml:"requestTimeout"`
+	QueryTimeout   time.Duration `yaml:"queryTimeout"`
+}
+
+type GRPCConfig struct {
+	Addr       string `yaml:"addr"`
+	Reflection bool   `yaml:"reflection"`
+}
+
+type RepositoryConfig struct {
+	// Backend is memory, file or postgres.
+	Backend     string `yaml:"backend"`
+	DatabaseURL string `yaml:"databaseURL"`
+	IDFormat    string `yaml:"idFormat"`
+	// SeedMowers are the names of the mowers the memory backend starts with,
+	// and the file backend the first time it starts.
+	SeedMowers []string `yaml:"seedMowers"`
+	// DataDir is where the file backend keeps its journal.
+	DataDir string `yaml:"dataDir"`
+	// Fsync is the repository.SyncPolicy of the file backend.
+	Fsync string `yaml:"fsync"`
+	// FsyncInterval is how often the interval policy flushes the journal.
+	FsyncInterval time.Duration `yaml:"fsyncInterval"`
+	SnapshotEvery int           `yaml:"snapshotEvery"`
+}
+
+// CacheConfig puts a repository.CachedRepo in front of the catalog
+// repository when Enabled.
+type CacheConfig struct {
+	Enabled bool          `yaml:"enabled"`
+	Size    int           `yaml:"size"`
+	TTL     time.Duration `yaml:"ttl"`
+	// NegativeTTL is how long unknown ids are remembered, never when 0.
+	NegativeTTL time.Duration `yaml:"negativeTTL"`
+}
+
+func Default() *Config {
+	return &Config{
+		HTTP: HTTPConfig{
+			Addr:         ":5001",
+			ReadTimeout:  10 * time.Second,
+			WriteTimeout: 10 * time.Second,
+			IdleTimeout:  60 * time.Second,
+
+			RequestTimeout: 5 * time.Second,
+			QueryTimeout:   15 * time.Second,
+		},
+		GRPC: GRPCConfig{Addr: ":5002"},
+		Repository: RepositoryConfig{
+			Backend:       BackendMemory,
+			IDFormat:      idgen.FormatUUIDv7,
+			SeedMowers:    []string{"M-90", "M-150", "M-480"},
+			Fsync:         string(repository.SyncAlways),
+			FsyncInterval: repository.DefaultSyncInterval,
+			SnapshotEvery: repository.DefaultSnapshotEvery,
+		},
+		Cache: CacheConfig{
+			Size:        repository.DefaultCacheSize,
+			TTL:         repository.DefaultCacheTTL,
+			NegativeTTL: repository.DefaultCacheNegativeTTL,
+		},
+		ShutdownTimeout:     15 * time.Second,
+		DrainDelay:          5 * time.Second,
+		HealthCheckInterval: 10 * time.Second,
+		LogLevel:            LogLevelInfo,
+	}
+}
+
+// Error lists every problem found in a configuration, so that they can all
+// be fixed at once.
+type Error struct {
+	Problems []string
+}
+
+func (e *Error) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// Load builds the configuration out of the command line args, without the
+// program name, and getenv. The file is given with --config or
+// CATALOG_CONFIG. printConfig tells whether --print-config was given.
+func Load(args []string, getenv func(string) string) (cfg *Config, printConfig bool, err error) {
+	fs := flag.NewFlagSet("catalog-service", flag.ContinueOnError)
+
+	path := fs.String("config", getenv("CATALOG_CONFIG"), "YAML configuration file, also CATALOG_CONFIG")
+	fs.BoolVar(&printConfig, "print-config", false, "print the configuration, secrets redacted, and exit")
+
+	defaults := Default()
+	flags := map[string]*rawValue{}
+
+	for _, s := range settings {
+		_, isBool := s.value(defaults).(*boolValue)
+		flags[s.flag] = &rawValue{raw: s.value(defaults).String(), isBool: isBool}
+		fs.Var(flags[s.flag], s.flag, fmt.Sprintf("%s, also %s", s.usage, s.env))
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, false, err
+	}
+
+	if fs.NArg() > 0 {
+		return nil, false, &Error{Problems: []string{fmt.Sprintf("unexpected arguments %v", fs.Args())}}
+	}
+
+	cfg = Default()
+	problems := []string{}
+
+	if *path != "" {
+		problems = append(problems, cfg.loadFile(*path)...)
+	}
+
+	for _, s := range settings {
+		if raw := getenv(s.env); raw != "" {
+			if err := s.value(cfg).Set(raw); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %v", s.env, err))
+			}
+		}
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		if s, ok := settingsByFlag[f.Name]; ok {
+			if err := s.value(cfg).Set(flags[f.Name].raw); err != nil {
+				problems = append(problems, fmt.Sprintf("--%s: %v", f.Name, err))
+			}
+		}
+	})
+
+	problems = append(problems, cfg.validate()...)
+
+	if len(problems) > 0 {
+		return nil, false, &Error{Problems: problems}
+	}
+
+	return cfg, printConfig, nil
+}
+
+// loadFile overrides cfg with the settings of the YAML file at path,
+// rejecting unknown keys.
+func (cfg *Config) loadFile(path string) []string {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+
+	err = decoder.Decode(cfg)
+
+	var typeErr *yaml.TypeError
+
+	switch {
+	case err == nil, errors.Is(err, io.EOF):
+		return nil
+	case errors.As(err, &typeErr):
+		problems := []string{}
+
+		for _, e := range typeErr.Errors {
+			problems = append(problems, fmt.Sprintf("%s: %s", path, e))
+		}
+
+		return problems
+	default:
+		return []string{fmt.Sprintf("%s: %v", path, err)}
+	}
+}
+
+func (cfg *Config) validate() []string {
+	problems := []string{}
+
+	invalid := func(name, format string, args ...interface{}) {
+		problems = append(problems, name+": "+fmt.Sprintf(format, args...))
+	}
+
+	for name, addr := range map[string]string{"http.addr": cfg.HTTP.Addr, "grpc.addr": cfg.GRPC.Addr} {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			invalid(name, "%q is not a host:port address", addr)
+		}
+	}
+
+	if cfg.HTTP.Addr == cfg.GRPC.Addr {
+		invalid("grpc.addr", "must differ from http.addr")
+	}
+
+	for name, d := range map[string]time.Duration{
+		"http.readTimeout":    cfg.HTTP.ReadTimeout,
+		"http.writeTimeout":   cfg.HTTP.WriteTimeout,
+		"http.idleTimeout":    cfg.HTTP.IdleTimeout,
+		"http.requestTimeout": cfg.HTTP.RequestTimeout,
+		"http.queryTimeout":   cfg.HTTP.QueryTimeout,
+		"shutdownTimeout":     cfg.ShutdownTimeout,
+		"healthCheckInterval": cfg.HealthCheckInterval,
+	} {
+		if d <= 0 {
+			invalid(name, "must be positive, got %v", d)
+		}
+	}
+
+	if cfg.DrainDelay < 0 || cfg.DrainDelay >= cfg.ShutdownTimeout {
+		invalid("drainDelay", "must be between 0s and shutdownTimeout (%v), got %v", cfg.ShutdownTimeout, cfg.DrainDelay)
+	}
+
+	if _, err := idgen.New(cfg.Repository.IDFormat); err != nil {
+		invalid("repository.idFormat", "%v", err)
+	}
+
+	switch cfg.Repository.Backend {
+	case BackendMemory, BackendFile:
+		for _, name := range cfg.Repository.SeedMowers {
+			if strings.TrimSpace(name) == "" {
+				invalid("repository.seedMowers", "names must not be blank")
+			}
+		}
+
+		if cfg.Repository.Backend != BackendFile {
+			break
+		}
+
+		if cfg.Repository.DataDir == "" {
+			invalid("repository.dataDir", "is required by the file backend")
+		}
+
+		if !validSyncPolicy(cfg.Repository.Fsync) {
+			invalid("repository.fsync", "must be one of %v, got %q", repository.SyncPolicies(), cfg.Repository.Fsync)
+		}
+
+		if cfg.Repository.FsyncInterval <= 0 {
+			invalid("repository.fsyncInterval", "must be positive, got %s", cfg.Repository.FsyncInterval)
+		}
+
+		if cfg.Repository.SnapshotEvery <= 0 {
+			invalid("repository.snapshotEvery", "must be positive, got %d", cfg.Repository.SnapshotEvery)
+		}
+	case BackendPostgres:
+		if cfg.Repository.DatabaseURL == "" {
+			invalid("repository.databaseURL", "is required by the postgres backend")
+		}
+
+		if !idgen.IsUUID(cfg.Repository.IDFormat) {
+			invalid("repository.idFormat", "postgres stores uuid ids, %q is not supported", cfg.Repository.IDFormat)
+		}
+	default:
+		invalid("repository.backend", "must be %s, %s or %s, got %q", BackendMemory, BackendFile, BackendPostgres, cfg.Repository.Backend)
+	}
+
+	if cfg.Cache.Enabled {
+		if cfg.Cache.Size <= 0 {
+			invalid("cache.size", "must be positive, got %d", cfg.Cache.Size)
+		}
+
+		if cfg.Cache.TTL <= 0 {
+			invalid("cache.ttl", "must be positive, got %v", cfg.Cache.TTL)
+		}
+
+		if cfg.Cache.NegativeTTL < 0 {
+			invalid("cache.negativeTTL", "must not be negative, got %v", cfg.Cache.NegativeTTL)
+		}
+	}
+
+	if cfg.LogLevel != LogLevelDebug && cfg.LogLevel != LogLevelInfo {
+		invalid("logLevel", "must be %s or %s, got %q", LogLevelDebug, LogLevelInfo, cfg.LogLevel)
+	}
+
+	// maps are iterated in random order
+	sort.Strings(problems)
+
+	return problems
+}
+
+func validSyncPolicy(policy string) bool {
+	for _, valid := range repository.SyncPolicies() {
+		if policy == string(valid) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Redacted returns a copy of cfg fit to be printed: the admin token and the
+// database password, in the user info or the query of the URL, are replaced.
+func (cfg *Config) Redacted() *Config {
+	copied := *cfg
+
+	if copied.AdminToken != "" {
+		copied.AdminToken = redacted
+	}
+
+	if dsn := copied.Repository.DatabaseURL; dsn != "" {
+		if u, err := url.Parse(dsn); err == nil && u.Scheme != "" {
+			if _, ok := u.User.Password(); ok {
+				u.User = url.UserPassword(u.User.Username(), redacted)
+			}
+
+			if query := u.Query(); query.Has("password") {
+				query.Set("password", redacted)
+				u.RawQuery = query.Encode()
+			}
+
+			copied.Repository.DatabaseURL = u.String()
+		} else {
+			copied.Repository.DatabaseURL = redacted
+		}
+	}
+
+	return &copied
+}
+
+// Print writes cfg, secrets redacted, as YAML that Load reads back.
+func (cfg *Config) Print(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(cfg.Redacted()); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
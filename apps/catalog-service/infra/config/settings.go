@@ -0,0 +1,196 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// setting is a configuration value that can be given as an environment
+// variable and as a flag.
+type setting struct {
+	flag  string
+	env   string
+	usage string
+	value func(cfg *Config) value
+}
+
+// value parses the text of environment variables and flags into a field of
+// Config.
+type value interface {
+	Set(raw string) error
+	String() string
+}
+
+var settings = []setting{
+	{"http-addr", "CATALOG_HTTP_ADDR", "HTTP listen address", func(c *Config) value { return (*stringValue)(&c.HTTP.Addr) }},
+	{"http-read-timeout", "CATALOG_HTTP_READ_TIMEOUT", "HTTP request read timeout", func(c *Config) value { return (*durationValue)(&c.HTTP.ReadTimeout) }},
+	{"http-write-timeout", "CATALOG_HTTP_WRITE_TIMEOUT", "HTTP response write timeout", func(c *Config) value { return (*durationValue)(&c.HTTP.WriteTimeout) }},
+	{"http-idle-timeout", "CATALOG_HTTP_IDLE_TIMEOUT", "HTTP keep-alive timeout", func(c *Config) value { return (*durationValue)(&c.HTTP.IdleTimeout) }},
+	{"http-request-timeout", "CATALOG_HTTP_REQUEST_TIMEOUT", "deadline of the requests on a single resource", func(c *Config) value { return (*durationValue)(&c.HTTP.RequestTimeout) }},
+	{"http-query-timeout", "CATALOG_HTTP_QUERY_TIMEOUT", "deadline of the listing and search requests", func(c *Config) value { return (*durationValue)(&c.HTTP.QueryTimeout) }},
+	{"grpc-addr", "CATALOG_GRPC_ADDR", "gRPC listen address", func(c *Config) value { return (*stringValue)(&c.GRPC.Addr) }},
+	{"grpc-reflection", "CATALOG_GRPC_REFLECTION", "enable gRPC server reflection", func(c *Config) value { return (*boolValue)(&c.GRPC.Reflection) }},
+	{"repository", "CATALOG_REPOSITORY", "repository backend, memory, file or postgres", func(c *Config) value { return (*stringValue)(&c.Repository.Backend) }},
+	{"database-url", "CATALOG_DATABASE_URL", "Postgres DSN of the postgres backend", func(c *Config) value { return (*stringValue)(&c.Repository.DatabaseURL) }},
+	{"id-format", "CATALOG_ID_FORMAT", "format of new ids: uuidv4, uuidv7, ulid or sequential", func(c *Config) value { return (*stringValue)(&c.Repository.IDFormat) }},
+	{"seed-mowers", "CATALOG_SEED_MOWERS", "comma separated names of the mowers the memory backend starts with", func(c *Config) value { return (*listValue)(&c.Repository.SeedMowers) }},
+	{"data-dir", "CATALOG_DATA_DIR", "directory of the journal of the file backend", func(c *Config) value { return (*stringValue)(&c.Repository.DataDir) }},
+	{"fsync", "CATALOG_FSYNC", "when the file backend flushes its journal: always, interval or never", func(c *Config) value { return (*stringValue)(&c.Repository.Fsync) }},
+	{"fsync-interval", "CATALOG_FSYNC_INTERVAL", "how often the interval fsync policy flushes the journal", func(c *Config) value { return (*durationValue)(&c.Repository.FsyncInterval) }},
+	{"snapshot-every", "CATALOG_SNAPSHOT_EVERY", "number of journal records the file backend compacts into a snapshot", func(c *Config) value { return (*intValue)(&c.Repository.SnapshotEvery) }},
+	{"cache", "CATALOG_CACHE", "cache the mowers found by id", func(c *Config) value { return (*boolValue)(&c.Cache.Enabled) }},
+	{"cache-size", "CATALOG_CACHE_SIZE", "number of mowers the cache keeps", func(c *Config) value { return (*intValue)(&c.Cache.Size) }},
+	{"cache-ttl", "CATALOG_CACHE_TTL", "time a mower is served from the cache", func(c *Config) value { return (*durationValue)(&c.Cache.TTL) }},
+	{"cache-negative-ttl", "CATALOG_CACHE_NEGATIVE_TTL", "time an unknown id is remembered by the cache, 0s for never", func(c *Config) value { return (*durationValue)(&c.Cache.NegativeTTL) }},
+	{"admin-token", "CATALOG_ADMIN_TOKEN", "bearer token of the admin operations", func(c *Config) value { return (*stringValue)(&c.AdminToken) }},
+	{"require-if-match", "CATALOG_REQUIRE_IF_MATCH", "require If-Match on PATCH /mowers/:id", func(c *Config) value { return (*boolValue)(&c.RequireIfMatch) }},
+	{"shutdown-timeout", "CATALOG_SHUTDOWN_TIMEOUT", "time given to requests in flight on shutdown", func(c *Config) value { return (*durationValue)(&c.ShutdownTimeout) }},
+	{"drain-delay", "CATALOG_DRAIN_DELAY", "time the HTTP server keeps serving once not ready on shutdown", func(c *Config) value { return (*durationValue)(&c.DrainDelay) }},
+	{"health-check-interval", "CATALOG_HEALTH_CHECK_INTERVAL", "interval of the gRPC health checks", func(c *Config) value { return (*durationValue)(&c.HealthCheckInterval) }},
+	{"log-level", "CATALOG_LOG_LEVEL", "info, or debug to log every HTTP request", func(c *Config) value { return (*stringValue)(&c.LogLevel) }},
+}
+
+var settingsByFlag = func() map[string]setting {
+	byFlag := map[string]setting{}
+
+	for _, s := range settings {
+		byFlag[s.flag] = s
+	}
+
+	return byFlag
+}()
+
+type stringValue string
+
+func (v *stringValue) Set(raw string) error {
+	*v = stringValue(raw)
+	return nil
+}
+
+func (v *stringValue) String() string {
+	if v == nil {
+		return ""
+	}
+
+	return string(*v)
+}
+
+type boolValue bool
+
+func (v *boolValue) Set(raw string) error {
+	b, err := strconv.ParseBool(raw)
+
+	if err != nil {
+		return err
+	}
+
+	*v = boolValue(b)
+
+	return nil
+}
+
+func (v *boolValue) String() string {
+	if v == nil {
+		return "false"
+	}
+
+	return strconv.FormatBool(bool(*v))
+}
+
+// IsBoolFlag lets boolean flags be given without a value.
+func (v *boolValue) IsBoolFlag() bool {
+	return true
+}
+
+type intValue int
+
+func (v *intValue) Set(raw string) error {
+	n, err := strconv.Atoi(raw)
+
+	if err != nil {
+		return err
+	}
+
+	*v = intValue(n)
+
+	return nil
+}
+
+func (v *intValue) String() string {
+	if v == nil {
+		return "0"
+	}
+
+	return strconv.Itoa(int(*v))
+}
+
+type durationValue time.Duration
+
+func (v *durationValue) Set(raw string) error {
+	d, err := time.ParseDuration(raw)
+
+	if err != nil {
+		return err
+	}
+
+	*v = durationValue(d)
+
+	return nil
+}
+
+func (v *durationValue) String() string {
+	if v == nil {
+		return "0s"
+	}
+
+	return time.Duration(*v).String()
+}
+
+type listValue []string
+
+func (v *listValue) Set(raw string) error {
+	list := []string{}
+
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+
+	*v = list
+
+	return nil
+}
+
+func (v *listValue) String() string {
+	if v == nil {
+		return ""
+	}
+
+	return strings.Join(*v, ",")
+}
+
+// rawValue keeps the text of a flag, for Load to parse it once the file and
+// the environment have been applied.
+type rawValue struct {
+	raw    string
+	isBool bool
+}
+
+func (v *rawValue) Set(raw string) error {
+	v.raw = raw
+	return nil
+}
+
+func (v *rawValue) String() string {
+	if v == nil {
+		return ""
+	}
+
+	return v.raw
+}
+
+func (v *rawValue) IsBoolFlag() bool {
+	return v.isBool
+}
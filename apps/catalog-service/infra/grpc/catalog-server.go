@@ -0,0 +1,259 @@
+package grpccontroller
+
+import (
+	"context"
+	"jrobic/lawn-mower/catalog-service/domain"
+	pb "jrobic/lawn-mower/catalog-service/infra/grpc/catalogpb"
+	"jrobic/lawn-mower/catalog-service/infra/healthcheck"
+	"jrobic/lawn-mower/catalog-service/usecase"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// CatalogGRPCServer serves the catalog use cases over gRPC. Handlers return
+// domain errors as they are, errorInterceptor turns them into statuses.
+type CatalogGRPCServer struct {
+	pb.UnimplementedCatalogServiceServer
+
+	Server      *grpc.Server
+	service     usecase.CatalogService
+	health      *health.Server
+	checker     *healthcheck.HealthChecker
+	reflection  bool
+	grpcOptions []grpc.ServerOption
+}
+
+type ServerOption func(*CatalogGRPCServer)
+
+// WithGRPCOptions passes opts on to the underlying grpc.Server.
+func WithGRPCOptions(opts ...grpc.ServerOption) ServerOption {
+	return func(s *CatalogGRPCServer) {
+		s.grpcOptions = append(s.grpcOptions, opts...)
+	}
+}
+
+// WithReflection registers the server reflection service, for tools such as
+// grpcurl to list and call the API without its proto files.
+func WithReflection() ServerOption {
+	return func(s *CatalogGRPCServer) {
+		s.reflection = true
+	}
+}
+
+// WithHealthChecker makes the catalog service NOT_SERVING whenever a check
+// registered in checker fails or checker is draining.
+func WithHealthChecker(checker *healthcheck.HealthChecker) ServerOption {
+	return func(s *CatalogGRPCServer) {
+		s.checker = checker
+	}
+}
+
+// NewCatalogGRPCServer returns a server with the catalog and the
+// grpc.health.v1.Health services registered, ready to Serve.
+func NewCatalogGRPCServer(service usecase.CatalogService, opts ...ServerOption) *CatalogGRPCServer {
+	s := &CatalogGRPCServer{service: service, health: health.NewServer()}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.checker == nil {
+		s.checker = healthcheck.NewHealthChecker()
+	}
+
+	s.Server = grpc.NewServer(append(s.grpcOptions, grpc.ChainUnaryInterceptor(errorInterceptor))...)
+
+	pb.RegisterCatalogServiceServer(s.Server, s)
+	healthpb.RegisterHealthServer(s.Server, s.health)
+
+	if s.reflection {
+		reflection.Register(s.Server)
+	}
+
+	s.setServingStatus(healthpb.HealthCheckResponse_SERVING)
+
+	return s
+}
+
+func (s *CatalogGRPCServer) CreateMower(ctx context.Context, req *pb.CreateMowerRequest) (*pb.Mower, error) {
+	mower, err := s.service.CreateMower(ctx, domain.CreateMowerDTO{Name: req.Name, Specs: specsFromProto(req.Specs)})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return mowerToProto(mower), nil
+}
+
+func (s *CatalogGRPCServer) UpdateMower(ctx context.Context, req *pb.UpdateMowerRequest) (*pb.Mower, error) {
+	input := domain.UpdateMowerDTO{Name: req.GetName(), Specs: specsPatchFromProto(req.Specs)}
+
+	mower, err := s.service.UpdateMower(ctx, req.Id, input, req.ExpectedVersion)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return mowerToProto(mower), nil
+}
+
+func (s *CatalogGRPCServer) GetMower(ctx context.Context, req *pb.GetMowerRequest) (*pb.Mower, error) {
+	mower, err := s.service.GetMower(ctx, req.Id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return mowerToProto(mower), nil
+}
+
+func (s *CatalogGRPCServer) DeleteMower(ctx context.Context, req *pb.DeleteMowerRequest) (*pb.Mower, error) {
+	mower, err := s.service.DeleteMower(ctx, req.Id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return mowerToProto(mower), nil
+}
+
+func (s *CatalogGRPCServer) RestoreMower(ctx context.Context, req *pb.RestoreMowerRequest) (*pb.Mower, error) {
+	mower, err := s.service.RestoreMower(ctx, req.Id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return mowerToProto(mower), nil
+}
+
+// ListMowers pages through the live mowers, page tokens being the cursors
+// of the REST API.
+func (s *CatalogGRPCServer) ListMowers(ctx context.Context, req *pb.ListMowersRequest) (*pb.ListMowersResponse, error) {
+	query := domain.PageQuery{Limit: int(req.PageSize), CountTotal: req.CountTotal}
+
+	if req.PageToken != "" {
+		cursor, err := domain.DecodeCursor(req.PageToken)
+
+		if err != nil {
+			return nil, err
+		}
+
+		query.Cursor = cursor
+	}
+
+	page, err := s.service.GetMowerPage(ctx, query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.ListMowersResponse{Mowers: mowersToProto(page.Mowers)}
+
+	if page.Next != nil {
+		res.NextPageToken = page.Next.Encode()
+	}
+
+	if page.Prev != nil {
+		res.PrevPageToken = page.Prev.Encode()
+	}
+
+	if page.Total != nil {
+		res.TotalSize = int32(*page.Total)
+	}
+
+	return res, nil
+}
+
+func (s *CatalogGRPCServer) CreateStore(ctx context.Context, req *pb.CreateStoreRequest) (*pb.Store, error) {
+	store, err := s.service.CreateStore(ctx, domain.CreateStoreDTO{Name: req.Name})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return storeToProto(store), nil
+}
+
+func (s *CatalogGRPCServer) UpdateStore(ctx context.Context, req *pb.UpdateStoreRequest) (*pb.Store, error) {
+	store, err := s.service.UpdateStore(ctx, req.Id, domain.UpdateStoreDTO{Name: req.Name})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return storeToProto(store), nil
+}
+
+func (s *CatalogGRPCServer) GetStore(ctx context.Context, req *pb.GetStoreRequest) (*pb.Store, error) {
+	store, err := s.service.GetStore(ctx, req.Id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return storeToProto(store), nil
+}
+
+func (s *CatalogGRPCServer) ListStoreMowers(ctx context.Context, req *pb.ListStoreMowersRequest) (*pb.ListStoreMowersResponse, error) {
+	mowers, err := s.service.GetStoreMowers(ctx, req.StoreId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListStoreMowersResponse{Mowers: mowersToProto(mowers)}, nil
+}
+
+func (s *CatalogGRPCServer) RegisterUnit(ctx context.Context, req *pb.RegisterUnitRequest) (*pb.InventoryUnit, error) {
+	unit, err := s.service.RegisterUnit(ctx, req.StoreId, domain.RegisterUnitDTO{SerialNumber: req.SerialNumber, ModelID: req.ModelId})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return unitToProto(unit), nil
+}
+
+func (s *CatalogGRPCServer) RetireUnit(ctx context.Context, req *pb.RetireUnitRequest) (*pb.InventoryUnit, error) {
+	unit, err := s.service.RetireUnit(ctx, req.StoreId, req.UnitId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return unitToProto(unit), nil
+}
+
+func (s *CatalogGRPCServer) MoveUnit(ctx context.Context, req *pb.MoveUnitRequest) (*pb.InventoryUnit, error) {
+	unit, err := s.service.MoveUnit(ctx, req.StoreId, req.UnitId, req.ToStoreId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return unitToProto(unit), nil
+}
+
+func (s *CatalogGRPCServer) ListStoreInventory(ctx context.Context, req *pb.ListStoreInventoryRequest) (*pb.ListInventoryResponse, error) {
+	units, err := s.service.GetStoreInventory(ctx, req.StoreId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListInventoryResponse{Units: unitsToProto(units)}, nil
+}
+
+func (s *CatalogGRPCServer) ListModelInventory(ctx context.Context, req *pb.ListModelInventoryRequest) (*pb.ListInventoryResponse, error) {
+	units, err := s.service.GetModelInventory(ctx, req.ModelId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListInventoryResponse{Units: unitsToProto(units)}, nil
+}
@@ -0,0 +1,296 @@
+package grpccontroller
+
+import (
+	"context"
+	"errors"
+	lmTesting "jrobic/lawn-mower/catalog-service"
+	"jrobic/lawn-mower/catalog-service/domain"
+	pb "jrobic/lawn-mower/catalog-service/infra/grpc/catalogpb"
+	"jrobic/lawn-mower/catalog-service/usecase"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// newTestClient serves service on an in-process listener and returns a
+// client connected to it.
+func newTestClient(t *testing.T, service usecase.CatalogService, opts ...ServerOption) pb.CatalogServiceClient {
+	return pb.NewCatalogServiceClient(newTestConn(t, NewCatalogGRPCServer(service, opts...)))
+}
+
+// newTestConn serves server on an in-process listener and returns a
+// connection to it.
+func newTestConn(t *testing.T, server *CatalogGRPCServer) *grpc.ClientConn {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+
+	go server.Server.Serve(listener)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	lmTesting.AssertNoError(t, err)
+
+	t.Cleanup(func() {
+		conn.Close()
+		server.Server.Stop()
+	})
+
+	return conn
+}
+
+func newTestService() usecase.CatalogService {
+	repo := &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{
+		{ID: "1", Name: "M-90", Version: 1},
+		{ID: "2", Name: "M-150", Version: 1},
+		{ID: "3", Name: "M-480", Version: 1},
+	}}
+	stores := &lmTesting.StubStoreRepository{Stores: []*domain.Store{{ID: "1", Name: "Lyon"}, {ID: "2", Name: "Paris"}}}
+	inventory := &lmTesting.StubInventoryRepository{Units: []*domain.InventoryUnit{
+		{ID: "1", SerialNumber: "SN-1", ModelID: "2", StoreID: "1"},
+	}}
+	repo.Inventory = inventory
+
+	return usecase.NewCatalogService(repo, usecase.WithStoreRepository(stores), usecase.WithInventoryRepository(inventory))
+}
+
+func assertCode(t testing.TB, err error, want codes.Code) *status.Status {
+	t.Helper()
+
+	s, ok := status.FromError(err)
+
+	if !ok || s.Code() != want {
+		t.Fatalf("got %v want code %v", err, want)
+	}
+
+	return s
+}
+
+func TestMowersGRPC(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, newTestService())
+
+	t.Run("CreateMower then GetMower", func(t *testing.T) {
+		created, err := client.CreateMower(ctx, &pb.CreateMowerRequest{
+			Name:  "M-500",
+			Specs: &pb.MowerSpecs{PowerSource: "battery", CuttingWidthCm: 46},
+		})
+		lmTesting.AssertNoError(t, err)
+
+		got, err := client.GetMower(ctx, &pb.GetMowerRequest{Id: created.Id})
+		lmTesting.AssertNoError(t, err)
+
+		if !proto.Equal(got, created) || got.Specs.CuttingWidthCm != 46 || got.CreateTime == nil {
+			t.Errorf("got %v want %v", got, created)
+		}
+	})
+
+	t.Run("UpdateMower only changes the given fields", func(t *testing.T) {
+		updated, err := client.UpdateMower(ctx, &pb.UpdateMowerRequest{
+			Id:              "1",
+			Specs:           &pb.MowerSpecsPatch{WeightKg: proto.Float64(22.5)},
+			ExpectedVersion: 1,
+		})
+		lmTesting.AssertNoError(t, err)
+
+		if updated.Name != "M-90" || updated.Specs.WeightKg != 22.5 || updated.Version != 2 {
+			t.Errorf("got %v want M-90 of 22.5kg at version 2", updated)
+		}
+	})
+
+	t.Run("DeleteMower then RestoreMower", func(t *testing.T) {
+		deleted, err := client.DeleteMower(ctx, &pb.DeleteMowerRequest{Id: "3"})
+		lmTesting.AssertNoError(t, err)
+
+		if deleted.DeleteTime == nil {
+			t.Errorf("expected delete_time to be set, got %v", deleted)
+		}
+
+		restored, err := client.RestoreMower(ctx, &pb.RestoreMowerRequest{Id: "3"})
+		lmTesting.AssertNoError(t, err)
+
+		if restored.DeleteTime != nil {
+			t.Errorf("expected delete_time to be cleared, got %v", restored)
+		}
+	})
+
+	t.Run("ListMowers pages with tokens", func(t *testing.T) {
+		first, err := client.ListMowers(ctx, &pb.ListMowersRequest{PageSize: 2, CountTotal: true})
+		lmTesting.AssertNoError(t, err)
+
+		if len(first.Mowers) != 2 || first.NextPageToken == "" || first.PrevPageToken != "" || first.TotalSize != 4 {
+			t.Fatalf("got %v want the first 2 of 4 mowers", first)
+		}
+
+		second, err := client.ListMowers(ctx, &pb.ListMowersRequest{PageSize: 2, PageToken: first.NextPageToken})
+		lmTesting.AssertNoError(t, err)
+
+		if len(second.Mowers) != 2 || second.NextPageToken != "" || second.PrevPageToken == "" {
+			t.Errorf("got %v want the last 2 mowers", second)
+		}
+	})
+}
+
+func TestStoresGRPC(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, newTestService())
+
+	t.Run("CreateStore, UpdateStore then GetStore", func(t *testing.T) {
+		created, err := client.CreateStore(ctx, &pb.CreateStoreRequest{Name: "Nice"})
+		lmTesting.AssertNoError(t, err)
+
+		_, err = client.UpdateStore(ctx, &pb.UpdateStoreRequest{Id: created.Id, Name: "Nice Est"})
+		lmTesting.AssertNoError(t, err)
+
+		got, err := client.GetStore(ctx, &pb.GetStoreRequest{Id: created.Id})
+		lmTesting.AssertNoError(t, err)
+
+		if got.Name != "Nice Est" {
+			t.Errorf("got %v want store Nice Est", got)
+		}
+	})
+
+	t.Run("inventory round trip", func(t *testing.T) {
+		unit, err := client.RegisterUnit(ctx, &pb.RegisterUnitRequest{StoreId: "1", SerialNumber: "SN-2", ModelId: "1"})
+		lmTesting.AssertNoError(t, err)
+
+		_, err = client.MoveUnit(ctx, &pb.MoveUnitRequest{StoreId: "1", UnitId: unit.Id, ToStoreId: "2"})
+		lmTesting.AssertNoError(t, err)
+
+		paris, err := client.ListStoreInventory(ctx, &pb.ListStoreInventoryRequest{StoreId: "2"})
+		lmTesting.AssertNoError(t, err)
+
+		if len(paris.Units) != 1 || paris.Units[0].SerialNumber != "SN-2" {
+			t.Errorf("got %v want unit SN-2", paris.Units)
+		}
+
+		mowers, err := client.ListStoreMowers(ctx, &pb.ListStoreMowersRequest{StoreId: "2"})
+		lmTesting.AssertNoError(t, err)
+
+		if len(mowers.Mowers) != 1 || mowers.Mowers[0].Name != "M-90" {
+			t.Errorf("got %v want M-90", mowers.Mowers)
+		}
+
+		_, err = client.RetireUnit(ctx, &pb.RetireUnitRequest{StoreId: "2", UnitId: unit.Id})
+		lmTesting.AssertNoError(t, err)
+
+		models, err := client.ListModelInventory(ctx, &pb.ListModelInventoryRequest{ModelId: "1"})
+		lmTesting.AssertNoError(t, err)
+
+		if len(models.Units) != 0 {
+			t.Errorf("got %v want no live unit", models.Units)
+		}
+	})
+}
+
+func TestErrorsGRPC(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, newTestService())
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := client.GetMower(ctx, &pb.GetMowerRequest{Id: "6"})
+
+		s := assertCode(t, err, codes.NotFound)
+
+		if s.Message() != domain.MowerNotFound("6").Error() {
+			t.Errorf("got message %q", s.Message())
+		}
+	})
+
+	t.Run("InvalidArgument with field violations", func(t *testing.T) {
+		_, err := client.CreateMower(ctx, &pb.CreateMowerRequest{Name: "M-900", Specs: &pb.MowerSpecs{CuttingWidthCm: 5}})
+
+		s := assertCode(t, err, codes.InvalidArgument)
+
+		if len(s.Details()) != 1 {
+			t.Fatalf("got details %v want a BadRequest", s.Details())
+		}
+
+		badRequest, ok := s.Details()[0].(*errdetails.BadRequest)
+
+		if !ok || len(badRequest.FieldViolations) != 1 || badRequest.FieldViolations[0].Field != "specs.cuttingWidthCm" {
+			t.Errorf("got details %v want a violation on specs.cuttingWidthCm", s.Details())
+		}
+	})
+
+	t.Run("InvalidArgument on malformed page token", func(t *testing.T) {
+		_, err := client.ListMowers(ctx, &pb.ListMowersRequest{PageToken: "not-a-token"})
+
+		assertCode(t, err, codes.InvalidArgument)
+	})
+
+	t.Run("Aborted on version conflict", func(t *testing.T) {
+		_, err := client.UpdateMower(ctx, &pb.UpdateMowerRequest{Id: "2", Name: proto.String("M-151"), ExpectedVersion: 7})
+
+		assertCode(t, err, codes.Aborted)
+	})
+
+	t.Run("AlreadyExists on serial number taken", func(t *testing.T) {
+		_, err := client.RegisterUnit(ctx, &pb.RegisterUnitRequest{StoreId: "1", SerialNumber: "SN-1", ModelId: "2"})
+
+		assertCode(t, err, codes.AlreadyExists)
+	})
+
+	t.Run("FailedPrecondition on deleting a stocked mower", func(t *testing.T) {
+		_, err := client.DeleteMower(ctx, &pb.DeleteMowerRequest{Id: "2"})
+
+		assertCode(t, err, codes.FailedPrecondition)
+	})
+
+	t.Run("Unavailable without stores", func(t *testing.T) {
+		client := newTestClient(t, usecase.NewCatalogService(&lmTesting.StubCatalogRepository{}))
+
+		_, err := client.GetStore(ctx, &pb.GetStoreRequest{Id: "1"})
+
+		assertCode(t, err, codes.Unavailable)
+	})
+
+	t.Run("DeadlineExceeded once the call deadline passes", func(t *testing.T) {
+		repo := &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{{ID: "1", Name: "M-90"}}, Delay: time.Second}
+		client := newTestClient(t, usecase.NewCatalogService(repo))
+
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+
+		_, err := client.GetMower(ctx, &pb.GetMowerRequest{Id: "1"})
+
+		assertCode(t, err, codes.DeadlineExceeded)
+	})
+}
+
+func TestCancelledCallGRPC(t *testing.T) {
+	repo := &lmTesting.StubCatalogRepository{Delay: time.Second}
+	server := NewCatalogGRPCServer(usecase.NewCatalogService(repo))
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	_, err := server.GetMower(ctx, &pb.GetMowerRequest{Id: "1"})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v want %v", err, context.Canceled)
+	}
+
+	if elapsed := time.Since(start); elapsed >= repo.Delay {
+		t.Errorf("took %v, the repository call should have been aborted", elapsed)
+	}
+
+	assertCode(t, toStatus("GetMower", err).Err(), codes.Canceled)
+}
@@ -0,0 +1,627 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: catalog.proto
+
+package catalogpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	CatalogService_CreateMower_FullMethodName        = "/lawnmower.catalog.v1.CatalogService/CreateMower"
+	CatalogService_UpdateMower_FullMethodName        = "/lawnmower.catalog.v1.CatalogService/UpdateMower"
+	CatalogService_GetMower_FullMethodName           = "/lawnmower.catalog.v1.CatalogService/GetMower"
+	CatalogService_DeleteMower_FullMethodName        = "/lawnmower.catalog.v1.CatalogService/DeleteMower"
+	CatalogService_RestoreMower_FullMethodName       = "/lawnmower.catalog.v1.CatalogService/RestoreMower"
+	CatalogService_ListMowers_FullMethodName         = "/lawnmower.catalog.v1.CatalogService/ListMowers"
+	CatalogService_CreateStore_FullMethodName        = "/lawnmower.catalog.v1.CatalogService/CreateStore"
+	CatalogService_UpdateStore_FullMethodName        = "/lawnmower.catalog.v1.CatalogService/UpdateStore"
+	CatalogService_GetStore_FullMethodName           = "/lawnmower.catalog.v1.CatalogService/GetStore"
+	CatalogService_ListStoreMowers_FullMethodName    = "/lawnmower.catalog.v1.CatalogService/ListStoreMowers"
+	CatalogService_RegisterUnit_FullMethodName       = "/lawnmower.catalog.v1.CatalogService/RegisterUnit"
+	CatalogService_RetireUnit_FullMethodName         = "/lawnmower.catalog.v1.CatalogService/RetireUnit"
+	CatalogService_MoveUnit_FullMethodName           = "/lawnmower.catalog.v1.CatalogService/MoveUnit"
+	CatalogService_ListStoreInventory_FullMethodName = "/lawnmower.catalog.v1.CatalogService/ListStoreInventory"
+	CatalogService_ListModelInventory_FullMethodName = "/lawnmower.catalog.v1.CatalogService/ListModelInventory"
+)
+
+// CatalogServiceClient is the client API for CatalogService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CatalogServiceClient interface {
+	CreateMower(ctx context.Context, in *CreateMowerRequest, opts ...grpc.CallOption) (*Mower, error)
+	UpdateMower(ctx context.Context, in *UpdateMowerRequest, opts ...grpc.CallOption) (*Mower, error)
+	GetMower(ctx context.Context, in *GetMowerRequest, opts ...grpc.CallOption) (*Mower, error)
+	DeleteMower(ctx context.Context, in *DeleteMowerRequest, opts ...grpc.CallOption) (*Mower, error)
+	RestoreMower(ctx context.Context, in *RestoreMowerRequest, opts ...grpc.CallOption) (*Mower, error)
+	ListMowers(ctx context.Context, in *ListMowersRequest, opts ...grpc.CallOption) (*ListMowersResponse, error)
+	CreateStore(ctx context.Context, in *CreateStoreRequest, opts ...grpc.CallOption) (*Store, error)
+	UpdateStore(ctx context.Context, in *UpdateStoreRequest, opts ...grpc.CallOption) (*Store, error)
+	GetStore(ctx context.Context, in *GetStoreRequest, opts ...grpc.CallOption) (*Store, error)
+	ListStoreMowers(ctx context.Context, in *ListStoreMowersRequest, opts ...grpc.CallOption) (*ListStoreMowersResponse, error)
+	RegisterUnit(ctx context.Context, in *RegisterUnitRequest, opts ...grpc.CallOption) (*InventoryUnit, error)
+	RetireUnit(ctx context.Context, in *RetireUnitRequest, opts ...grpc.CallOption) (*InventoryUnit, error)
+	MoveUnit(ctx context.Context, in *MoveUnitRequest, opts ...grpc.CallOption) (*InventoryUnit, error)
+	ListStoreInventory(ctx context.Context, in *ListStoreInventoryRequest, opts ...grpc.CallOption) (*ListInventoryResponse, error)
+	ListModelInventory(ctx context.Context, in *ListModelInventoryRequest, opts ...grpc.CallOption) (*ListInventoryResponse, error)
+}
+
+type catalogServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCatalogServiceClient(cc grpc.ClientConnInterface) CatalogServiceClient {
+	return &catalogServiceClient{cc}
+}
+
+func (c *catalogServiceClient) CreateMower(ctx context.Context, in *CreateMowerRequest, opts ...grpc.CallOption) (*Mower, error) {
+	out := new(Mower)
+	err := c.cc.Invoke(ctx, CatalogService_CreateMower_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) UpdateMower(ctx context.Context, in *UpdateMowerRequest, opts ...grpc.CallOption) (*Mower, error) {
+	out := new(Mower)
+	err := c.cc.Invoke(ctx, CatalogService_UpdateMower_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) GetMower(ctx context.Context, in *GetMowerRequest, opts ...grpc.CallOption) (*Mower, error) {
+	out := new(Mower)
+	err := c.cc.Invoke(ctx, CatalogService_GetMower_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) DeleteMower(ctx context.Context, in *DeleteMowerRequest, opts ...grpc.CallOption) (*Mower, error) {
+	out := new(Mower)
+	err := c.cc.Invoke(ctx, CatalogService_DeleteMower_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) RestoreMower(ctx context.Context, in *RestoreMowerRequest, opts ...grpc.CallOption) (*Mower, error) {
+	out := new(Mower)
+	err := c.cc.Invoke(ctx, CatalogService_RestoreMower_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ListMowers(ctx context.Context, in *ListMowersRequest, opts ...grpc.CallOption) (*ListMowersResponse, error) {
+	out := new(ListMowersResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ListMowers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) CreateStore(ctx context.Context, in *CreateStoreRequest, opts ...grpc.CallOption) (*Store, error) {
+	out := new(Store)
+	err := c.cc.Invoke(ctx, CatalogService_CreateStore_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) UpdateStore(ctx context.Context, in *UpdateStoreRequest, opts ...grpc.CallOption) (*Store, error) {
+	out := new(Store)
+	err := c.cc.Invoke(ctx, CatalogService_UpdateStore_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) GetStore(ctx context.Context, in *GetStoreRequest, opts ...grpc.CallOption) (*Store, error) {
+	out := new(Store)
+	err := c.cc.Invoke(ctx, CatalogService_GetStore_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ListStoreMowers(ctx context.Context, in *ListStoreMowersRequest, opts ...grpc.CallOption) (*ListStoreMowersResponse, error) {
+	out := new(ListStoreMowersResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ListStoreMowers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) RegisterUnit(ctx context.Context, in *RegisterUnitRequest, opts ...grpc.CallOption) (*InventoryUnit, error) {
+	out := new(InventoryUnit)
+	err := c.cc.Invoke(ctx, CatalogService_RegisterUnit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) RetireUnit(ctx context.Context, in *RetireUnitRequest, opts ...grpc.CallOption) (*InventoryUnit, error) {
+	out := new(InventoryUnit)
+	err := c.cc.Invoke(ctx, CatalogService_RetireUnit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) MoveUnit(ctx context.Context, in *MoveUnitRequest, opts ...grpc.CallOption) (*InventoryUnit, error) {
+	out := new(InventoryUnit)
+	err := c.cc.Invoke(ctx, CatalogService_MoveUnit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ListStoreInventory(ctx context.Context, in *ListStoreInventoryRequest, opts ...grpc.CallOption) (*ListInventoryResponse, error) {
+	out := new(ListInventoryResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ListStoreInventory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ListModelInventory(ctx context.Context, in *ListModelInventoryRequest, opts ...grpc.CallOption) (*ListInventoryResponse, error) {
+	out := new(ListInventoryResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ListModelInventory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CatalogServiceServer is the server API for CatalogService service.
+// All implementations must embed UnimplementedCatalogServiceServer
+// for forward compatibility
+type CatalogServiceServer interface {
+	CreateMower(context.Context, *CreateMowerRequest) (*Mower, error)
+	UpdateMower(context.Context, *UpdateMowerRequest) (*Mower, error)
+	GetMower(context.Context, *GetMowerRequest) (*Mower, error)
+	DeleteMower(context.Context, *DeleteMowerRequest) (*Mower, error)
+	RestoreMower(context.Context, *RestoreMowerRequest) (*Mower, error)
+	ListMowers(context.Context, *ListMowersRequest) (*ListMowersResponse, error)
+	CreateStore(context.Context, *CreateStoreRequest) (*Store, error)
+	UpdateStore(context.Context, *UpdateStoreRequest) (*Store, error)
+	GetStore(context.Context, *GetStoreRequest) (*Store, error)
+	ListStoreMowers(context.Context, *ListStoreMowersRequest) (*ListStoreMowersResponse, error)
+	RegisterUnit(context.Context, *RegisterUnitRequest) (*InventoryUnit, error)
+	RetireUnit(context.Context, *RetireUnitRequest) (*InventoryUnit, error)
+	MoveUnit(context.Context, *MoveUnitRequest) (*InventoryUnit, error)
+	ListStoreInventory(context.Context, *ListStoreInventoryRequest) (*ListInventoryResponse, error)
+	ListModelInventory(context.Context, *ListModelInventoryRequest) (*ListInventoryResponse, error)
+	mustEmbedUnimplementedCatalogServiceServer()
+}
+
+// UnimplementedCatalogServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCatalogServiceServer struct {
+}
+
+func (UnimplementedCatalogServiceServer) CreateMower(context.Context, *CreateMowerRequest) (*Mower, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMower not implemented")
+}
+func (UnimplementedCatalogServiceServer) UpdateMower(context.Context, *UpdateMowerRequest) (*Mower, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMower not implemented")
+}
+func (UnimplementedCatalogServiceServer) GetMower(context.Context, *GetMowerRequest) (*Mower, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMower not implemented")
+}
+func (UnimplementedCatalogServiceServer) DeleteMower(context.Context, *DeleteMowerRequest) (*Mower, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMower not implemented")
+}
+func (UnimplementedCatalogServiceServer) RestoreMower(context.Context, *RestoreMowerRequest) (*Mower, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreMower not implemented")
+}
+func (UnimplementedCatalogServiceServer) ListMowers(context.Context, *ListMowersRequest) (*ListMowersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMowers not implemented")
+}
+func (UnimplementedCatalogServiceServer) CreateStore(context.Context, *CreateStoreRequest) (*Store, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateStore not implemented")
+}
+func (UnimplementedCatalogServiceServer) UpdateStore(context.Context, *UpdateStoreRequest) (*Store, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateStore not implemented")
+}
+func (UnimplementedCatalogServiceServer) GetStore(context.Context, *GetStoreRequest) (*Store, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStore not implemented")
+}
+func (UnimplementedCatalogServiceServer) ListStoreMowers(context.Context, *ListStoreMowersRequest) (*ListStoreMowersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStoreMowers not implemented")
+}
+func (UnimplementedCatalogServiceServer) RegisterUnit(context.Context, *RegisterUnitRequest) (*InventoryUnit, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterUnit not implemented")
+}
+func (UnimplementedCatalogServiceServer) RetireUnit(context.Context, *RetireUnitRequest) (*InventoryUnit, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetireUnit not implemented")
+}
+func (UnimplementedCatalogServiceServer) MoveUnit(context.Context, *MoveUnitRequest) (*InventoryUnit, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveUnit not implemented")
+}
+func (UnimplementedCatalogServiceServer) ListStoreInventory(context.Context, *ListStoreInventoryRequest) (*ListInventoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStoreInventory not implemented")
+}
+func (UnimplementedCatalogServiceServer) ListModelInventory(context.Context, *ListModelInventoryRequest) (*ListInventoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListModelInventory not implemented")
+}
+func (UnimplementedCatalogServiceServer) mustEmbedUnimplementedCatalogServiceServer() {}
+
+// UnsafeCatalogServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CatalogServiceServer will
+// result in compilation errors.
+type UnsafeCatalogServiceServer interface {
+	mustEmbedUnimplementedCatalogServiceServer()
+}
+
+func RegisterCatalogServiceServer(s grpc.ServiceRegistrar, srv CatalogServiceServer) {
+	s.RegisterService(&CatalogService_ServiceDesc, srv)
+}
+
+func _CatalogService_CreateMower_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMowerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).CreateMower(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_CreateMower_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).CreateMower(ctx, req.(*CreateMowerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_UpdateMower_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMowerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).UpdateMower(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_UpdateMower_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).UpdateMower(ctx, req.(*UpdateMowerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_GetMower_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMowerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetMower(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetMower_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetMower(ctx, req.(*GetMowerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_DeleteMower_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMowerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).DeleteMower(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_DeleteMower_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).DeleteMower(ctx, req.(*DeleteMowerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_RestoreMower_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreMowerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).RestoreMower(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_RestoreMower_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).RestoreMower(ctx, req.(*RestoreMowerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ListMowers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMowersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ListMowers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ListMowers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ListMowers(ctx, req.(*ListMowersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_CreateStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).CreateStore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_CreateStore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).CreateStore(ctx, req.(*CreateStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_UpdateStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).UpdateStore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_UpdateStore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).UpdateStore(ctx, req.(*UpdateStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_GetStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetStore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetStore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetStore(ctx, req.(*GetStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ListStoreMowers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStoreMowersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ListStoreMowers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ListStoreMowers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ListStoreMowers(ctx, req.(*ListStoreMowersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_RegisterUnit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterUnitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).RegisterUnit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_RegisterUnit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).RegisterUnit(ctx, req.(*RegisterUnitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_RetireUnit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetireUnitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).RetireUnit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_RetireUnit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).RetireUnit(ctx, req.(*RetireUnitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_MoveUnit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveUnitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).MoveUnit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_MoveUnit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).MoveUnit(ctx, req.(*MoveUnitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ListStoreInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStoreInventoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ListStoreInventory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ListStoreInventory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ListStoreInventory(ctx, req.(*ListStoreInventoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ListModelInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListModelInventoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ListModelInventory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ListModelInventory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ListModelInventory(ctx, req.(*ListModelInventoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CatalogService_ServiceDesc is the grpc.ServiceDesc for CatalogService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CatalogService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "lawnmower.catalog.v1.CatalogService",
+	HandlerType: (*CatalogServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateMower",
+			Handler:    _CatalogService_CreateMower_Handler,
+		},
+		{
+			MethodName: "UpdateMower",
+			Handler:    _CatalogService_UpdateMower_Handler,
+		},
+		{
+			MethodName: "GetMower",
+			Handler:    _CatalogService_GetMower_Handler,
+		},
+		{
+			MethodName: "DeleteMower",
+			Handler:    _CatalogService_DeleteMower_Handler,
+		},
+		{
+			MethodName: "RestoreMower",
+			Handler:    _CatalogService_RestoreMower_Handler,
+		},
+		{
+			MethodName: "ListMowers",
+			Handler:    _CatalogService_ListMowers_Handler,
+		},
+		{
+			MethodName: "CreateStore",
+			Handler:    _CatalogService_CreateStore_Handler,
+		},
+		{
+			MethodName: "UpdateStore",
+			Handler:    _CatalogService_UpdateStore_Handler,
+		},
+		{
+			MethodName: "GetStore",
+			Handler:    _CatalogService_GetStore_Handler,
+		},
+		{
+			MethodName: "ListStoreMowers",
+			Handler:    _CatalogService_ListStoreMowers_Handler,
+		},
+		{
+			MethodName: "RegisterUnit",
+			Handler:    _CatalogService_RegisterUnit_Handler,
+		},
+		{
+			MethodName: "RetireUnit",
+			Handler:    _CatalogService_RetireUnit_Handler,
+		},
+		{
+			MethodName: "MoveUnit",
+			Handler:    _CatalogService_MoveUnit_Handler,
+		},
+		{
+			MethodName: "ListStoreInventory",
+			Handler:    _CatalogService_ListStoreInventory_Handler,
+		},
+		{
+			MethodName: "ListModelInventory",
+			Handler:    _CatalogService_ListModelInventory_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "catalog.proto",
+}
@@ -0,0 +1,5 @@
+// Package catalogpb is the gRPC API of the catalog, generated from
+// catalog.proto.
+package catalogpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative catalog.proto
@@ -0,0 +1,136 @@
+package grpccontroller
+
+import (
+	"jrobic/lawn-mower/catalog-service/domain"
+	pb "jrobic/lawn-mower/catalog-service/infra/grpc/catalogpb"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func timestampToProto(t *domain.Timestamp) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(time.Time(*t))
+}
+
+func mowerToProto(mower *domain.Mower) *pb.Mower {
+	specs := mower.Specs
+
+	return &pb.Mower{
+		Id:         mower.ID,
+		CreateTime: timestampToProto(mower.CreatedAt),
+		UpdateTime: timestampToProto(mower.UpdatedAt),
+		DeleteTime: timestampToProto(mower.DeletedAt),
+		Version:    mower.Version,
+		Name:       mower.Name,
+		Specs: &pb.MowerSpecs{
+			PowerSource:        string(specs.PowerSource),
+			CuttingWidthCm:     int32(specs.CuttingWidthCm),
+			CuttingHeightMinMm: int32(specs.CuttingHeightMinMm),
+			CuttingHeightMaxMm: int32(specs.CuttingHeightMaxMm),
+			GrassBagLiters:     int32(specs.GrassBagLiters),
+			WeightKg:           specs.WeightKg,
+			SelfPropelled:      specs.SelfPropelled,
+			RecommendedAreaM2:  int32(specs.RecommendedAreaM2),
+			Description:        specs.Description,
+		},
+	}
+}
+
+func mowersToProto(mowers []*domain.Mower) []*pb.Mower {
+	converted := make([]*pb.Mower, 0, len(mowers))
+
+	for _, mower := range mowers {
+		converted = append(converted, mowerToProto(mower))
+	}
+
+	return converted
+}
+
+func specsFromProto(specs *pb.MowerSpecs) domain.MowerSpecs {
+	if specs == nil {
+		return domain.MowerSpecs{}
+	}
+
+	return domain.MowerSpecs{
+		PowerSource:        domain.PowerSource(specs.PowerSource),
+		CuttingWidthCm:     int(specs.CuttingWidthCm),
+		CuttingHeightMinMm: int(specs.CuttingHeightMinMm),
+		CuttingHeightMaxMm: int(specs.CuttingHeightMaxMm),
+		GrassBagLiters:     int(specs.GrassBagLiters),
+		WeightKg:           specs.WeightKg,
+		SelfPropelled:      specs.SelfPropelled,
+		RecommendedAreaM2:  int(specs.RecommendedAreaM2),
+		Description:        specs.Description,
+	}
+}
+
+func specsPatchFromProto(patch *pb.MowerSpecsPatch) *domain.MowerSpecsPatch {
+	if patch == nil {
+		return nil
+	}
+
+	converted := &domain.MowerSpecsPatch{
+		WeightKg:      patch.WeightKg,
+		SelfPropelled: patch.SelfPropelled,
+		Description:   patch.Description,
+	}
+
+	if patch.PowerSource != nil {
+		source := domain.PowerSource(*patch.PowerSource)
+		converted.PowerSource = &source
+	}
+
+	converted.CuttingWidthCm = intPtr(patch.CuttingWidthCm)
+	converted.CuttingHeightMinMm = intPtr(patch.CuttingHeightMinMm)
+	converted.CuttingHeightMaxMm = intPtr(patch.CuttingHeightMaxMm)
+	converted.GrassBagLiters = intPtr(patch.GrassBagLiters)
+	converted.RecommendedAreaM2 = intPtr(patch.RecommendedAreaM2)
+
+	return converted
+}
+
+func intPtr(n *int32) *int {
+	if n == nil {
+		return nil
+	}
+
+	converted := int(*n)
+
+	return &converted
+}
+
+func storeToProto(store *domain.Store) *pb.Store {
+	return &pb.Store{
+		Id:         store.ID,
+		CreateTime: timestampToProto(store.CreatedAt),
+		UpdateTime: timestampToProto(store.UpdatedAt),
+		DeleteTime: timestampToProto(store.DeletedAt),
+		Name:       store.Name,
+	}
+}
+
+func unitToProto(unit *domain.InventoryUnit) *pb.InventoryUnit {
+	return &pb.InventoryUnit{
+		Id:           unit.ID,
+		CreateTime:   timestampToProto(unit.CreatedAt),
+		UpdateTime:   timestampToProto(unit.UpdatedAt),
+		DeleteTime:   timestampToProto(unit.DeletedAt),
+		SerialNumber: unit.SerialNumber,
+		ModelId:      unit.ModelID,
+		StoreId:      unit.StoreID,
+	}
+}
+
+func unitsToProto(units []*domain.InventoryUnit) []*pb.InventoryUnit {
+	converted := make([]*pb.InventoryUnit, 0, len(units))
+
+	for _, unit := range units {
+		converted = append(converted, unitToProto(unit))
+	}
+
+	return converted
+}
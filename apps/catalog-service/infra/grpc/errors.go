@@ -0,0 +1,83 @@
+package grpccontroller
+
+import (
+	"context"
+	"errors"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"log"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusCode maps a domain error kind, or the error of a call context, to the status code it is answered with.
+type statusCode struct {
+	kind error
+	code codes.Code
+}
+
+// statusCodes is checked in order, the specific errors coming before the
+// kind they belong to.
+var statusCodes = []statusCode{
+	{domain.ErrVersionConflict, codes.Aborted},
+	{domain.ErrSerialNumberTaken, codes.AlreadyExists},
+	{domain.ErrMalformedQuery, codes.InvalidArgument},
+	{domain.ErrNotFound, codes.NotFound},
+	{domain.ErrConflict, codes.FailedPrecondition},
+	{domain.ErrValidation, codes.InvalidArgument},
+	{domain.ErrUnavailable, codes.Unavailable},
+	{context.DeadlineExceeded, codes.DeadlineExceeded},
+	{context.Canceled, codes.Canceled},
+}
+
+// errorInterceptor answers every error returned by a handler with a status:
+// domain errors with the code of their kind, validation errors detailing
+// their fields in a BadRequest, and anything unexpected as Internal.
+func errorInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	res, err := handler(ctx, req)
+
+	if err == nil {
+		return res, nil
+	}
+
+	return nil, toStatus(info.FullMethod, err).Err()
+}
+
+func toStatus(method string, err error) *status.Status {
+	if s, ok := status.FromError(err); ok {
+		return s
+	}
+
+	for _, c := range statusCodes {
+		if !errors.Is(err, c.kind) {
+			continue
+		}
+
+		s := status.New(c.code, err.Error())
+
+		var validationErr *domain.ValidationError
+
+		if errors.As(err, &validationErr) {
+			details := &errdetails.BadRequest{}
+
+			for _, field := range validationErr.Fields {
+				details.FieldViolations = append(details.FieldViolations, &errdetails.BadRequest_FieldViolation{
+					Field:       field.Field,
+					Description: field.Message,
+				})
+			}
+
+			if detailed, err := s.WithDetails(details); err == nil {
+				return detailed
+			}
+		}
+
+		return s
+	}
+
+	log.Printf("call %s failed: %v", method, err)
+
+	return status.New(codes.Internal, "internal error")
+}
@@ -0,0 +1,72 @@
+package grpccontroller
+
+import (
+	"context"
+	pb "jrobic/lawn-mower/catalog-service/infra/grpc/catalogpb"
+	"log"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// CheckHealth runs the health checks and sets the status of the catalog
+// service, and of the server as a whole, accordingly: SERVING when they all
+// pass, NOT_SERVING otherwise. It does nothing once the server is shutting
+// down.
+func (s *CatalogGRPCServer) CheckHealth(ctx context.Context) healthpb.HealthCheckResponse_ServingStatus {
+	report := s.checker.Check(ctx)
+	status := healthpb.HealthCheckResponse_SERVING
+
+	if !report.Ready() {
+		log.Printf("not serving: %+v", report)
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	s.setServingStatus(status)
+
+	return status
+}
+
+// WatchHealth runs the health checks every interval until ctx is done.
+func (s *CatalogGRPCServer) WatchHealth(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.CheckHealth(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown reports every service NOT_SERVING, so that clients and load
+// balancers stop sending new calls, then waits for the pending ones to
+// finish before stopping the server. Once ctx is done it stops the server
+// right away, cancelling the calls still running.
+func (s *CatalogGRPCServer) Shutdown(ctx context.Context) error {
+	s.health.Shutdown()
+
+	done := make(chan struct{})
+
+	go func() {
+		s.Server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.Server.Stop()
+		return ctx.Err()
+	}
+}
+
+func (s *CatalogGRPCServer) setServingStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	s.health.SetServingStatus("", status)
+	s.health.SetServingStatus(pb.CatalogService_ServiceDesc.ServiceName, status)
+}
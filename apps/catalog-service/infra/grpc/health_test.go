@@ -0,0 +1,158 @@
+package grpccontroller
+
+import (
+	"context"
+	"errors"
+	lmTesting "jrobic/lawn-mower/catalog-service"
+	pb "jrobic/lawn-mower/catalog-service/infra/grpc/catalogpb"
+	"jrobic/lawn-mower/catalog-service/infra/healthcheck"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+)
+
+func TestHealthGRPC(t *testing.T) {
+	ctx := context.Background()
+
+	assertServing := func(t *testing.T, client healthpb.HealthClient, service string, want healthpb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+
+		res, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		lmTesting.AssertNoError(t, err)
+
+		if res.Status != want {
+			t.Errorf("got %v for %q want %v", res.Status, service, want)
+		}
+	}
+
+	t.Run("serving without checks", func(t *testing.T) {
+		client := healthpb.NewHealthClient(newTestConn(t, NewCatalogGRPCServer(newTestService())))
+
+		assertServing(t, client, "", healthpb.HealthCheckResponse_SERVING)
+		assertServing(t, client, pb.CatalogService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	})
+
+	t.Run("unknown service", func(t *testing.T) {
+		client := healthpb.NewHealthClient(newTestConn(t, NewCatalogGRPCServer(newTestService())))
+
+		_, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "unknown"})
+
+		assertCode(t, err, codes.NotFound)
+	})
+
+	t.Run("follow the health checks", func(t *testing.T) {
+		var pingErr error
+
+		checker := healthcheck.NewHealthChecker()
+		checker.Register("postgres", 0, func(ctx context.Context) error {
+			return pingErr
+		})
+
+		server := NewCatalogGRPCServer(newTestService(), WithHealthChecker(checker))
+		client := healthpb.NewHealthClient(newTestConn(t, server))
+
+		pingErr = errors.New("connection refused")
+		server.CheckHealth(ctx)
+
+		assertServing(t, client, "", healthpb.HealthCheckResponse_NOT_SERVING)
+		assertServing(t, client, pb.CatalogService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+
+		pingErr = nil
+		server.CheckHealth(ctx)
+
+		assertServing(t, client, pb.CatalogService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+
+		checker.Drain()
+		server.CheckHealth(ctx)
+
+		assertServing(t, client, pb.CatalogService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	})
+
+	t.Run("not serving once shutting down", func(t *testing.T) {
+		server := NewCatalogGRPCServer(newTestService())
+		client := healthpb.NewHealthClient(newTestConn(t, server))
+
+		watch, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: pb.CatalogService_ServiceDesc.ServiceName})
+		lmTesting.AssertNoError(t, err)
+
+		res, err := watch.Recv()
+		lmTesting.AssertNoError(t, err)
+
+		if res.Status != healthpb.HealthCheckResponse_SERVING {
+			t.Fatalf("got %v want SERVING", res.Status)
+		}
+
+		go server.Shutdown(ctx)
+
+		res, err = watch.Recv()
+		lmTesting.AssertNoError(t, err)
+
+		if res.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+			t.Errorf("got %v want NOT_SERVING", res.Status)
+		}
+
+		server.CheckHealth(ctx)
+
+		res, err = server.health.Check(ctx, &healthpb.HealthCheckRequest{})
+		lmTesting.AssertNoError(t, err)
+
+		if res.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+			t.Errorf("got %v after a passing check want NOT_SERVING until the server stops", res.Status)
+		}
+	})
+}
+
+func TestReflectionGRPC(t *testing.T) {
+	ctx := context.Background()
+
+	listServices := func(t *testing.T, opts ...ServerOption) ([]string, error) {
+		t.Helper()
+
+		client := reflectionpb.NewServerReflectionClient(newTestConn(t, NewCatalogGRPCServer(newTestService(), opts...)))
+
+		stream, err := client.ServerReflectionInfo(ctx)
+		lmTesting.AssertNoError(t, err)
+
+		err = stream.Send(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+		})
+		lmTesting.AssertNoError(t, err)
+
+		res, err := stream.Recv()
+
+		if err != nil {
+			return nil, err
+		}
+
+		names := []string{}
+
+		for _, service := range res.GetListServicesResponse().GetService() {
+			names = append(names, service.Name)
+		}
+
+		return names, nil
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		_, err := listServices(t)
+
+		assertCode(t, err, codes.Unimplemented)
+	})
+
+	t.Run("lists the services", func(t *testing.T) {
+		names, err := listServices(t, WithReflection())
+		lmTesting.AssertNoError(t, err)
+
+		found := false
+
+		for _, name := range names {
+			found = found || name == pb.CatalogService_ServiceDesc.ServiceName
+		}
+
+		if !found {
+			t.Errorf("got services %v want %s among them", names, pb.CatalogService_ServiceDesc.ServiceName)
+		}
+	})
+}
@@ -0,0 +1,163 @@
+package healthcheck
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout bounds the checks registered without a timeout of their own.
+const DefaultTimeout = 2 * time.Second
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Check reports whether a dependency can be reached, returning an error when
+// it cannot. It should give up once ctx is done.
+type Check func(ctx context.Context) error
+
+// HealthChecker is a registry of named dependency checks, such as the
+// database behind the repositories, telling whether the service is ready to
+// take traffic. Once draining, it reports the service as not ready whatever
+// its checks say, so that no new traffic comes in while shutting down.
+type HealthChecker struct {
+	lock     sync.RWMutex
+	checks   map[string]registeredCheck
+	draining bool
+}
+
+type registeredCheck struct {
+	check   Check
+	timeout time.Duration
+}
+
+func NewHealthChecker() *HealthChecker {
+	return &HealthChecker{checks: map[string]registeredCheck{}}
+}
+
+// Register adds check under name, replacing any check of the same name. A
+// check running longer than timeout, DefaultTimeout when zero, fails.
+func (hc *HealthChecker) Register(name string, timeout time.Duration, check Check) {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	hc.lock.Lock()
+	defer hc.lock.Unlock()
+
+	hc.checks[name] = registeredCheck{check: check, timeout: timeout}
+}
+
+// Drain reports the service as not ready from now on.
+func (hc *HealthChecker) Drain() {
+	hc.lock.Lock()
+	defer hc.lock.Unlock()
+
+	hc.draining = true
+}
+
+func (hc *HealthChecker) Draining() bool {
+	hc.lock.RLock()
+	defer hc.lock.RUnlock()
+
+	return hc.draining
+}
+
+// Report is the outcome of running every check.
+type Report struct {
+	Status     string                     `json:"status"`
+	Draining   bool                       `json:"draining,omitempty"`
+	Components map[string]ComponentReport `json:"components"`
+}
+
+type ComponentReport struct {
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// Ready tells whether every check passed and the service is not draining.
+func (r Report) Ready() bool {
+	return r.Status == StatusUp
+}
+
+// Check runs every check concurrently, each within its own timeout, and
+// reports them all.
+func (hc *HealthChecker) Check(ctx context.Context) Report {
+	hc.lock.RLock()
+	checks := make(map[string]registeredCheck, len(hc.checks))
+
+	for name, c := range hc.checks {
+		checks[name] = c
+	}
+
+	draining := hc.draining
+	hc.lock.RUnlock()
+
+	report := Report{Status: StatusUp, Draining: draining, Components: map[string]ComponentReport{}}
+
+	var (
+		wg   sync.WaitGroup
+		lock sync.Mutex
+	)
+
+	for name, c := range checks {
+		wg.Add(1)
+
+		go func(name string, c registeredCheck) {
+			defer wg.Done()
+
+			component := run(ctx, c)
+
+			lock.Lock()
+			defer lock.Unlock()
+
+			report.Components[name] = component
+
+			if component.Status != StatusUp {
+				report.Status = StatusDown
+			}
+		}(name, c)
+	}
+
+	wg.Wait()
+
+	if draining {
+		report.Status = StatusDown
+	}
+
+	return report
+}
+
+// run runs c, failing it when it outlives its timeout even if it does not
+// watch its context.
+func run(ctx context.Context, c registeredCheck) ComponentReport {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+
+	go func() {
+		done <- c.check(ctx)
+	}()
+
+	var err error
+
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	component := ComponentReport{Status: StatusUp, DurationMs: time.Since(start).Milliseconds()}
+
+	if err != nil {
+		component.Status = StatusDown
+		component.Error = err.Error()
+	}
+
+	return component
+}
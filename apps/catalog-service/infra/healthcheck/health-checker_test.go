@@ -0,0 +1,91 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHealthChecker(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("up without checks", func(t *testing.T) {
+		report := NewHealthChecker().Check(ctx)
+
+		if !report.Ready() || len(report.Components) != 0 {
+			t.Errorf("got %+v want up", report)
+		}
+	})
+
+	t.Run("down when a check fails", func(t *testing.T) {
+		checker := NewHealthChecker()
+		checker.Register("postgres", 0, func(ctx context.Context) error {
+			return errors.New("connection refused")
+		})
+		checker.Register("cache", 0, func(ctx context.Context) error {
+			return nil
+		})
+
+		report := checker.Check(ctx)
+
+		if report.Ready() {
+			t.Errorf("got %+v want down", report)
+		}
+
+		if got := report.Components["postgres"]; got.Status != StatusDown || got.Error != "connection refused" {
+			t.Errorf("got %+v want postgres down", got)
+		}
+
+		if got := report.Components["cache"]; got.Status != StatusUp || got.Error != "" {
+			t.Errorf("got %+v want cache up", got)
+		}
+	})
+
+	t.Run("fail checks outliving their timeout", func(t *testing.T) {
+		checker := NewHealthChecker()
+		release := make(chan struct{})
+		defer close(release)
+
+		checker.Register("publisher", 10*time.Millisecond, func(ctx context.Context) error {
+			<-release
+			return nil
+		})
+
+		start := time.Now()
+		report := checker.Check(ctx)
+
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("took %v want the timeout to cut the check short", elapsed)
+		}
+
+		if got := report.Components["publisher"]; got.Status != StatusDown || got.Error != context.DeadlineExceeded.Error() {
+			t.Errorf("got %+v want publisher timed out", got)
+		}
+	})
+
+	t.Run("replace checks of the same name", func(t *testing.T) {
+		checker := NewHealthChecker()
+		checker.Register("postgres", 0, func(ctx context.Context) error {
+			return errors.New("connection refused")
+		})
+		checker.Register("postgres", 0, func(ctx context.Context) error {
+			return nil
+		})
+
+		if report := checker.Check(ctx); !report.Ready() {
+			t.Errorf("got %+v want up", report)
+		}
+	})
+
+	t.Run("down while draining", func(t *testing.T) {
+		checker := NewHealthChecker()
+		checker.Drain()
+
+		report := checker.Check(ctx)
+
+		if report.Ready() || !report.Draining || !checker.Draining() {
+			t.Errorf("got %+v want draining", report)
+		}
+	})
+}
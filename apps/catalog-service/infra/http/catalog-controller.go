@@ -1,13 +1,20 @@
 package restcontroller
 
 import (
+	"context"
+	"crypto/subtle"
 	"jrobic/lawn-mower/catalog-service/domain"
+	"jrobic/lawn-mower/catalog-service/infra/healthcheck"
 	"jrobic/lawn-mower/catalog-service/usecase"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/compress"
 	"github.com/gofiber/fiber/v2/middleware/etag"
+	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/requestid"
 )
 
@@ -15,60 +22,261 @@ var (
 	JSONContentType = "application/json"
 )
 
+// Default deadlines of the requests, see WithRequestTimeouts.
+const (
+	DefaultRequestTimeout = 5 * time.Second
+	DefaultQueryTimeout   = 15 * time.Second
+)
+
 type CreateMowerInputDTO struct {
-	Name string `json:"name"`
+	Name  string            `json:"name"`
+	Specs domain.MowerSpecs `json:"specs"`
 }
 
 type UpdateMowerInputDTO struct {
-	Name string `json:"name,omitempty"`
+	Name  string                  `json:"name,omitempty"`
+	Specs *domain.MowerSpecsPatch `json:"specs,omitempty"`
 }
 
 type CatalogHTTPServer struct {
-	App     *fiber.App
-	repo    domain.CatalogRepository
-	service usecase.CatalogService
+	App            *fiber.App
+	repo           domain.CatalogRepository
+	stores         domain.StoreRepository
+	inventory      domain.InventoryRepository
+	index          domain.SearchIndex
+	health         *healthcheck.HealthChecker
+	service        usecase.CatalogService
+	adminToken     string
+	ids            domain.IDGenerator
+	requireIfMatch bool
+	readTimeout    time.Duration
+	writeTimeout   time.Duration
+	idleTimeout    time.Duration
+	requestTimeout time.Duration
+	queryTimeout   time.Duration
+	drainDelay     time.Duration
+	logRequests    bool
+}
+
+type ServerOption func(*CatalogHTTPServer)
+
+// WithAdminToken enables the admin operations for requests sending
+// `Authorization: Bearer <token>`. Without it they are always forbidden.
+func WithAdminToken(token string) ServerOption {
+	return func(s *CatalogHTTPServer) {
+		s.adminToken = token
+	}
+}
+
+// WithIDGenerator makes routes answer 400 Bad Request for `:id` params that
+// ids could not have generated. Any non-empty id is accepted otherwise.
+func WithIDGenerator(ids domain.IDGenerator) ServerOption {
+	return func(s *CatalogHTTPServer) {
+		s.ids = ids
+	}
+}
+
+// WithRequiredIfMatch makes PATCH /mowers/:id answer 428 Precondition
+// Required when the client does not send the ETag it based its change on.
+func WithRequiredIfMatch() ServerOption {
+	return func(s *CatalogHTTPServer) {
+		s.requireIfMatch = true
+	}
+}
+
+// WithStoreRepository serves the /stores routes from stores. Without it they
+// answer 503 Service Unavailable.
+func WithStoreRepository(stores domain.StoreRepository) ServerOption {
+	return func(s *CatalogHTTPServer) {
+		s.stores = stores
+	}
+}
+
+// WithInventoryRepository serves the inventory routes from inventory. Without
+// it they answer 503 Service Unavailable.
+func WithInventoryRepository(inventory domain.InventoryRepository) ServerOption {
+	return func(s *CatalogHTTPServer) {
+		s.inventory = inventory
+	}
+}
+
+// WithSearchIndex serves /mowers/search and /mowers/suggest from index, and
+// keeps it in sync with every change of a mower. Without it they answer 503
+// Service Unavailable.
+func WithSearchIndex(index domain.SearchIndex) ServerOption {
+	return func(s *CatalogHTTPServer) {
+		s.index = index
+	}
+}
+
+// WithTimeouts bounds the time to read a request, to write its response and
+// to keep an idle connection open. Zero means no limit, the default.
+func WithTimeouts(read, write, idle time.Duration) ServerOption {
+	return func(s *CatalogHTTPServer) {
+		s.readTimeout, s.writeTimeout, s.idleTimeout = read, write, idle
+	}
+}
+
+// WithRequestTimeouts sets the deadline of the requests: request for the
+// routes reading or changing a single resource, query for the listings and
+// searches. The repository calls of a request past its deadline are cancelled
+// and it answers 504 Gateway Timeout. Zero means no deadline. They default to
+// DefaultRequestTimeout and DefaultQueryTimeout.
+func WithRequestTimeouts(request, query time.Duration) ServerOption {
+	return func(s *CatalogHTTPServer) {
+		s.requestTimeout, s.queryTimeout = request, query
+	}
+}
+
+// WithDrainDelay keeps serving for delay once Shutdown reports the server as
+// not ready, so that load balancers polling /readyz stop routing requests to
+// it before it stops accepting them. Zero, the default, stops right away.
+func WithDrainDelay(delay time.Duration) ServerOption {
+	return func(s *CatalogHTTPServer) {
+		s.drainDelay = delay
+	}
+}
+
+// WithRequestLogging logs every request with its status and latency.
+func WithRequestLogging() ServerOption {
+	return func(s *CatalogHTTPServer) {
+		s.logRequests = true
+	}
+}
+
+// WithHealthChecker answers /readyz from the checks registered in health.
+// Without it the server is ready as soon as it starts.
+func WithHealthChecker(health *healthcheck.HealthChecker) ServerOption {
+	return func(s *CatalogHTTPServer) {
+		s.health = health
+	}
 }
 
-func NewCatalogHTTPServer(repo domain.CatalogRepository) (*CatalogHTTPServer, error) {
+func NewCatalogHTTPServer(repo domain.CatalogRepository, opts ...ServerOption) (*CatalogHTTPServer, error) {
 	s := new(CatalogHTTPServer)
 
 	s.repo = repo
-	s.service = usecase.NewCatalogService(repo)
+	s.requestTimeout = DefaultRequestTimeout
+	s.queryTimeout = DefaultQueryTimeout
 
-	app := fiber.New()
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.health == nil {
+		s.health = healthcheck.NewHealthChecker()
+	}
+
+	s.service = usecase.NewCatalogService(repo,
+		usecase.WithStoreRepository(s.stores),
+		usecase.WithInventoryRepository(s.inventory),
+		usecase.WithSearchIndex(s.index),
+	)
+
+	app := fiber.New(fiber.Config{
+		ErrorHandler:          errorHandler,
+		ReadTimeout:           s.readTimeout,
+		WriteTimeout:          s.writeTimeout,
+		IdleTimeout:           s.idleTimeout,
+		DisableStartupMessage: true,
+	})
 
 	app.Use(requestid.New())
+
+	if s.logRequests {
+		app.Use(logger.New(logger.Config{Format: "${time} ${locals:requestid} ${status} ${method} ${path} ${latency}\n"}))
+	}
+
 	app.Use(compress.New())
 	app.Use(etag.New())
 
-	app.Get("/", s.GetCatalog)
-	app.Post("/mowers", s.CreateMower)
-	app.Get("/mowers/:id", s.GetMower)
-	app.Patch("/mowers/:id", s.UpdateMower)
+	app.Get("/healthz", s.Liveness)
+	app.Get("/readyz", s.Readiness)
+
+	request := deadline(s.requestTimeout)
+	query := deadline(s.queryTimeout)
+
+	app.Get("/", query, s.GetMowers)
+	app.Get("/mowers", query, s.GetMowers)
+	app.Post("/mowers", request, s.CreateMower)
+	app.Get("/mowers/search", query, s.SearchMowers)
+	app.Get("/mowers/suggest", query, s.SuggestMowers)
+	app.Get("/mowers/:id", s.validateID, request, s.GetMower)
+	app.Patch("/mowers/:id", s.validateID, request, s.UpdateMower)
+	app.Delete("/mowers/:id", s.validateID, request, s.DeleteMower)
+	app.Post("/mowers/:id/restore", s.validateID, request, s.RestoreMower)
+	app.Get("/mowers/:id/inventory", s.validateID, query, s.GetMowerInventory)
+
+	app.Post("/stores", request, s.CreateStore)
+	app.Get("/stores/:id", s.validateID, request, s.GetStore)
+	app.Patch("/stores/:id", s.validateID, request, s.UpdateStore)
+	app.Get("/stores/:id/mowers", s.validateID, query, s.GetStoreMowers)
+	app.Get("/stores/:id/inventory", s.validateID, query, s.GetStoreInventory)
+	app.Post("/stores/:id/inventory", s.validateID, request, s.RegisterUnit)
+	app.Delete("/stores/:id/inventory/:unitId", s.validateID, request, s.RetireUnit)
+	app.Post("/stores/:id/inventory/:unitId/move", s.validateID, request, s.MoveUnit)
+
+	admin := app.Group("/admin", s.requireAdmin)
+	admin.Delete("/mowers/:id", s.validateID, request, s.PurgeMower)
 
 	s.App = app
 
 	return s, nil
 }
 
+// Shutdown reports the server as not ready, keeps serving for the drain
+// delay, then stops accepting connections and waits for the requests in
+// flight to complete, giving up once ctx is done.
+func (serv *CatalogHTTPServer) Shutdown(ctx context.Context) error {
+	serv.health.Drain()
+
+	if serv.drainDelay > 0 {
+		delay := time.NewTimer(serv.drainDelay)
+		defer delay.Stop()
+
+		select {
+		case <-delay.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- serv.App.Shutdown()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func (serv *CatalogHTTPServer) CreateMower(c *fiber.Ctx) error {
 	c.Append("content-type", JSONContentType)
 
 	mowerToCreate := new(CreateMowerInputDTO)
 
-	err := c.BodyParser(mowerToCreate)
+	err := parseBody(c, mowerToCreate)
 
 	if err != nil {
-		return c.Status(http.StatusBadRequest).Send([]byte(err.Error()))
+		return err
 	}
 
-	mower, err := serv.service.CreateMower(domain.CreateMowerDTO{Name: mowerToCreate.Name})
+	mower, err := serv.service.CreateMower(c.UserContext(), domain.CreateMowerDTO{
+		Name:  mowerToCreate.Name,
+		Specs: mowerToCreate.Specs,
+	})
 
 	if err != nil {
-		return c.Status(http.StatusBadRequest).Send([]byte(err.Error()))
+		return err
 	}
 
 	c.Status(http.StatusAccepted)
+	setETag(c, mower)
 
 	return c.JSON(mower)
 }
@@ -78,12 +286,14 @@ func (serv *CatalogHTTPServer) GetMower(c *fiber.Ctx) error {
 
 	id := c.Params("id")
 
-	mower, err := serv.service.GetMower(id)
+	mower, err := serv.service.GetMower(c.UserContext(), id)
 
 	if err != nil {
-		return c.Status(http.StatusNotFound).Send([]byte(err.Error()))
+		return err
 	}
 
+	setETag(c, mower)
+
 	return c.Status(http.StatusOK).JSON(mower)
 }
 
@@ -92,33 +302,222 @@ func (serv *CatalogHTTPServer) UpdateMower(c *fiber.Ctx) error {
 
 	id := c.Params("id")
 
+	ifMatch := c.Get(fiber.HeaderIfMatch)
+
+	if ifMatch == "" && serv.requireIfMatch {
+		return fiber.NewError(http.StatusPreconditionRequired, "If-Match header is required")
+	}
+
+	expectedVersion, err := serv.matchETag(c.UserContext(), id, ifMatch)
+
+	if err != nil {
+		return err
+	}
+
 	mowerToUpdate := new(UpdateMowerInputDTO)
-	err := c.BodyParser(&mowerToUpdate)
+	err = parseBody(c, mowerToUpdate)
 
 	if err != nil {
-		return c.Status(http.StatusBadRequest).Send([]byte(err.Error()))
+		return err
 	}
 
-	mower, err := serv.service.UpdateMower(id, domain.UpdateMowerDTO{
-		Name: mowerToUpdate.Name,
-	})
+	mower, err := serv.service.UpdateMower(c.UserContext(), id, domain.UpdateMowerDTO{
+		Name:  mowerToUpdate.Name,
+		Specs: mowerToUpdate.Specs,
+	}, expectedVersion)
+
+	if err != nil {
+		return err
+	}
+
+	setETag(c, mower)
+
+	return c.Status(http.StatusOK).JSON(mower)
+
+}
+
+func (serv *CatalogHTTPServer) DeleteMower(c *fiber.Ctx) error {
+	c.Append("content-type", JSONContentType)
+
+	mower, err := serv.service.DeleteMower(c.UserContext(), c.Params("id"))
+
+	if err != nil {
+		return err
+	}
+
+	setETag(c, mower)
+
+	return c.Status(http.StatusOK).JSON(mower)
+}
+
+func (serv *CatalogHTTPServer) RestoreMower(c *fiber.Ctx) error {
+	c.Append("content-type", JSONContentType)
+
+	mower, err := serv.service.RestoreMower(c.UserContext(), c.Params("id"))
 
 	if err != nil {
-		return c.Status(http.StatusBadRequest).Send([]byte(err.Error()))
+		return err
 	}
 
+	setETag(c, mower)
+
 	return c.Status(http.StatusOK).JSON(mower)
+}
+
+func (serv *CatalogHTTPServer) PurgeMower(c *fiber.Ctx) error {
+	err := serv.service.PurgeMower(c.UserContext(), c.Params("id"))
 
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(http.StatusNoContent)
 }
 
-func (serv *CatalogHTTPServer) GetCatalog(c *fiber.Ctx) error {
+// GetMowers answers one page of the mowers matching the filters of the
+// query string, e.g. `/mowers?powerSource=battery&cuttingWidthCm[gt]=40&store=1&sort=-weightKg`.
+// The cursors of the neighbour pages are given as `next` and `prev` links in
+// the Link header, and the total number of matching mowers in X-Total-Count
+// when asked with `total=true`. Deleted mowers are reserved to admins.
+func (serv *CatalogHTTPServer) GetMowers(c *fiber.Ctx) error {
 	c.Append("content-type", JSONContentType)
 
-	mowers, err := serv.service.GetAvailableMowers()
+	query, err := serv.parseMowerQuery(c)
+
+	if err != nil {
+		return err
+	}
+
+	if query.Availability != domain.OnlyAvailable && !serv.isAdmin(c) {
+		return fiber.NewError(http.StatusForbidden, "deleted mowers are reserved to admins")
+	}
+
+	page, err := serv.service.GetMowerPage(c.UserContext(), query)
 
 	if err != nil {
-		return c.Status(http.StatusInternalServerError).Send([]byte(err.Error()))
+		return err
+	}
+
+	setPageHeaders(c, page, query.Limit)
+
+	return c.Status(http.StatusOK).JSON(page.Mowers)
+}
+
+func (serv *CatalogHTTPServer) isAdmin(c *fiber.Ctx) bool {
+	if serv.adminToken == "" {
+		return false
+	}
+
+	got := []byte(c.Get(fiber.HeaderAuthorization))
+	want := []byte("Bearer " + serv.adminToken)
+
+	return subtle.ConstantTimeCompare(got, want) == 1
+}
+
+func (serv *CatalogHTTPServer) requireAdmin(c *fiber.Ctx) error {
+	if !serv.isAdmin(c) {
+		return fiber.NewError(http.StatusForbidden, "admin token required")
+	}
+
+	return c.Next()
+}
+
+// validateID checks every param of the route, `:id` as well as `:unitId`.
+func (serv *CatalogHTTPServer) validateID(c *fiber.Ctx) error {
+	if serv.ids == nil {
+		return c.Next()
+	}
+
+	for _, param := range c.Route().Params {
+		id := c.Params(param)
+
+		if !serv.ids.Valid(id) {
+			return domain.MalformedID(id)
+		}
+	}
+
+	return c.Next()
+}
+
+// deadline gives the request context of the next handlers a timeout, so that
+// the repository calls they make give up past it. It is all that stops them:
+// fasthttp does not report clients closing their connection to handlers.
+func deadline(timeout time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if timeout <= 0 {
+			return c.Next()
+		}
+
+		ctx, cancel := context.WithTimeout(c.UserContext(), timeout)
+		defer cancel()
+
+		c.SetUserContext(ctx)
+
+		return c.Next()
+	}
+}
+
+// setETag exposes the mower version as a strong entity tag.
+func setETag(c *fiber.Ctx, mower *domain.Mower) {
+	if mower != nil {
+		c.Set(fiber.HeaderETag, strconv.Quote(strconv.FormatInt(mower.Version, 10)))
+	}
+}
+
+// matchETag turns an If-Match value into the version the update expects, 0
+// meaning any version. Out of a list of tags, it expects the current version
+// when it is listed; the update still fails if the mower changes meanwhile.
+func (serv *CatalogHTTPServer) matchETag(ctx context.Context, id string, ifMatch string) (int64, error) {
+	versions, anyVersion := parseETag(ifMatch)
+
+	if anyVersion {
+		return 0, nil
+	}
+
+	switch len(versions) {
+	case 0:
+		return 0, domain.ErrVersionConflict
+	case 1:
+		return versions[0], nil
+	}
+
+	current, err := serv.service.GetMower(ctx, id)
+
+	if err != nil {
+		return 0, err
+	}
+
+	for _, version := range versions {
+		if version == current.Version {
+			return version, nil
+		}
+	}
+
+	return 0, domain.ErrVersionConflict
+}
+
+// parseETag reads the versions of the comma separated tags of an If-Match
+// value, reporting when it matches any version. Weak or foreign tags can
+// never match a version and are left out.
+func parseETag(ifMatch string) ([]int64, bool) {
+	if ifMatch == "" || strings.TrimSpace(ifMatch) == "*" {
+		return nil, true
+	}
+
+	versions := []int64{}
+
+	for _, tag := range strings.Split(ifMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		unquoted, err := strconv.Unquote(tag)
+
+		if err != nil || !strings.HasPrefix(tag, `"`) {
+			continue
+		}
+
+		if version, err := strconv.ParseInt(unquoted, 10, 64); err == nil && version > 0 {
+			versions = append(versions, version)
+		}
 	}
 
-	return c.JSON(mowers)
+	return versions, false
 }
@@ -4,19 +4,29 @@ import (
 	"fmt"
 	lmTesting "jrobic/lawn-mower/catalog-service"
 	"jrobic/lawn-mower/catalog-service/domain"
+	"jrobic/lawn-mower/catalog-service/infra/idgen"
 	"jrobic/lawn-mower/catalog-service/infra/repository"
 	"net/http"
 	"testing"
+	"time"
 )
 
+var testNow = time.Date(2022, time.July, 14, 10, 0, 0, 0, time.UTC)
+
 func TestCreateMowersAndRetrievingThemes(t *testing.T) {
-	repo := repository.NewInMemoryRepo([]*domain.Mower{})
+	repo := repository.NewInMemoryRepo(
+		[]*domain.Mower{},
+		repository.WithClock(lmTesting.NewFakeClock(testNow)),
+		repository.WithIDGenerator(idgen.NewSequential(0)),
+	)
 	server, _ := NewCatalogHTTPServer(repo)
 
+	now := domain.NewTimestamp(testNow)
+
 	wantedMowers := []*domain.Mower{
-		{ID: "1", Name: "M-90"},
-		{ID: "2", Name: "M-150"},
-		{ID: "3", Name: "M-480"},
+		{ID: "1", Name: "M-90", CreatedAt: now, UpdatedAt: now, Version: 1},
+		{ID: "2", Name: "M-150", CreatedAt: now, UpdatedAt: now, Version: 1},
+		{ID: "3", Name: "M-480", CreatedAt: now, UpdatedAt: now, Version: 1},
 	}
 
 	for _, wantedMower := range wantedMowers {
@@ -50,18 +60,20 @@ func TestCreateMowersAndRetrievingThemes(t *testing.T) {
 
 func TestUpdateMowersAndRetrievingThemes(t *testing.T) {
 	wantedMowers := []*domain.Mower{
-		{ID: "1", Name: "M-90"},
-		{ID: "2", Name: "M-150"},
-		{ID: "3", Name: "M-480"},
+		{ID: "1", Name: "M-90", Version: 1},
+		{ID: "2", Name: "M-150", Version: 1},
+		{ID: "3", Name: "M-480", Version: 1},
 	}
 
+	now := domain.NewTimestamp(testNow)
+
 	wantedUpdatedMowers := []*domain.Mower{
-		{ID: "1", Name: "M-90"},
-		{ID: "2", Name: "M-150"},
-		{ID: "3", Name: "M-390"},
+		{ID: "1", Name: "M-90", UpdatedAt: now, Version: 2},
+		{ID: "2", Name: "M-150", UpdatedAt: now, Version: 2},
+		{ID: "3", Name: "M-390", UpdatedAt: now, Version: 2},
 	}
 
-	repo := repository.NewInMemoryRepo(wantedMowers)
+	repo := repository.NewInMemoryRepo(wantedMowers, repository.WithClock(lmTesting.NewFakeClock(testNow)))
 	server, _ := NewCatalogHTTPServer(repo)
 
 	for _, wantedMower := range wantedUpdatedMowers {
@@ -5,10 +5,17 @@ import (
 	"encoding/json"
 
 	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	lmTesting "jrobic/lawn-mower/catalog-service"
 	"jrobic/lawn-mower/catalog-service/domain"
+	"jrobic/lawn-mower/catalog-service/infra/idgen"
+
+	"github.com/gofiber/fiber/v2"
 )
 
 func TestCreateMowerCtrl(t *testing.T) {
@@ -19,11 +26,12 @@ func TestCreateMowerCtrl(t *testing.T) {
 			{ID: "2", Name: "M-150"},
 			{ID: "3", Name: "M-480"},
 		}
-		repo := &lmTesting.StubCatalogRepository{Mowers: wantedCatalog}
+		repo := &lmTesting.StubCatalogRepository{Mowers: wantedCatalog, Clock: lmTesting.NewFakeClock(testNow)}
 		server, _ := NewCatalogHTTPServer(repo)
 
 		mower := &CreateMowerInputDTO{Name: "M-600"}
-		wantedMower := domain.Mower{Name: "M-600", ID: "4"}
+		now := domain.NewTimestamp(testNow)
+		wantedMower := domain.Mower{Name: "M-600", ID: "4", CreatedAt: now, UpdatedAt: now, Version: 1}
 
 		request := NewCreateMowerRequest(mower)
 
@@ -36,6 +44,67 @@ func TestCreateMowerCtrl(t *testing.T) {
 
 		lmTesting.AssertMowerEquals(t, got, wantedMower)
 	})
+
+	t.Run("CreateMowerCtrl store mower specs", func(t *testing.T) {
+		repo := &lmTesting.StubCatalogRepository{}
+		server, _ := NewCatalogHTTPServer(repo)
+
+		specs := domain.MowerSpecs{PowerSource: domain.PowerSourceRobotic, CuttingWidthCm: 22, RecommendedAreaM2: 1500}
+
+		response, _ := server.App.Test(NewCreateMowerRequest(CreateMowerInputDTO{Name: "M-600", Specs: specs}), -1)
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusAccepted)
+
+		if got := lmTesting.GetMowerFromResponse(t, response.Body); got.Specs != specs {
+			t.Errorf("got %+v want %+v", got.Specs, specs)
+		}
+	})
+
+	t.Run("CreateMowerCtrl return 422 listing invalid fields", func(t *testing.T) {
+		server, _ := NewCatalogHTTPServer(&lmTesting.StubCatalogRepository{})
+
+		specs := domain.MowerSpecs{PowerSource: "nuclear"}
+
+		response, _ := server.App.Test(NewCreateMowerRequest(CreateMowerInputDTO{Name: " ", Specs: specs}), -1)
+
+		got := AssertProblem(t, response, http.StatusUnprocessableEntity, "urn:lawn-mower:catalog:validation-failed")
+
+		if len(got.Errors) != 2 || got.Errors[0].Field != "name" || got.Errors[1].Field != "specs.powerSource" {
+			t.Errorf("got %+v want errors on name and specs.powerSource", got)
+		}
+	})
+
+	t.Run("CreateMowerCtrl return 422 on mistyped field", func(t *testing.T) {
+		server, _ := NewCatalogHTTPServer(&lmTesting.StubCatalogRepository{})
+
+		request, _ := http.NewRequest(http.MethodPost, "/mowers", strings.NewReader(`{"name":"M-600","specs":{"cuttingWidthCm":"wide"}}`))
+		request.Header.Set("Content-Type", "application/json")
+
+		response, _ := server.App.Test(request, -1)
+
+		got := AssertProblem(t, response, http.StatusUnprocessableEntity, "urn:lawn-mower:catalog:validation-failed")
+
+		want := []domain.FieldError{{Field: "specs.cuttingWidthCm", Message: "must be an integer"}}
+
+		if !reflect.DeepEqual(got.Errors, want) {
+			t.Errorf("got %+v want %+v", got.Errors, want)
+		}
+	})
+
+	t.Run("CreateMowerCtrl return 400 on malformed JSON", func(t *testing.T) {
+		server, _ := NewCatalogHTTPServer(&lmTesting.StubCatalogRepository{})
+
+		request, _ := http.NewRequest(http.MethodPost, "/mowers", strings.NewReader(`{"name":`))
+		request.Header.Set("Content-Type", "application/json")
+
+		response, _ := server.App.Test(request, -1)
+
+		problem := AssertProblem(t, response, http.StatusBadRequest, "about:blank")
+
+		if problem.Detail != "request body is not valid JSON" {
+			t.Errorf("got detail %q want %q", problem.Detail, "request body is not valid JSON")
+		}
+	})
 }
 
 func TestUpdateMowerCtrl(t *testing.T) {
@@ -45,6 +114,8 @@ func TestUpdateMowerCtrl(t *testing.T) {
 
 		wantedUpdatedMower := wantedMower
 		wantedUpdatedMower.Name = "M-380"
+		wantedUpdatedMower.UpdatedAt = domain.NewTimestamp(testNow)
+		wantedUpdatedMower.Version = 1
 
 		wantedCatalog := []*domain.Mower{
 			{ID: "1", Name: "M-90"},
@@ -52,7 +123,7 @@ func TestUpdateMowerCtrl(t *testing.T) {
 			&wantedMower,
 		}
 
-		repo := &lmTesting.StubCatalogRepository{Mowers: wantedCatalog}
+		repo := &lmTesting.StubCatalogRepository{Mowers: wantedCatalog, Clock: lmTesting.NewFakeClock(testNow)}
 		server, _ := NewCatalogHTTPServer(repo)
 
 		updateMower := domain.UpdateMowerDTO{Name: wantedUpdatedMower.Name}
@@ -70,6 +141,74 @@ func TestUpdateMowerCtrl(t *testing.T) {
 	})
 }
 
+func TestUpdateMowerIfMatchCtrl(t *testing.T) {
+	newServer := func(opts ...ServerOption) *CatalogHTTPServer {
+		repo := &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{{ID: "1", Name: "M-90", Version: 2}}}
+		server, _ := NewCatalogHTTPServer(repo, opts...)
+		return server
+	}
+
+	t.Run("GetMowerCtrl return version as strong ETag", func(t *testing.T) {
+		response, _ := newServer().App.Test(NewGetMowerRequest("1"), -1)
+
+		if got := response.Header.Get("ETag"); got != `"2"` {
+			t.Errorf("got ETag %q want %q", got, `"2"`)
+		}
+	})
+
+	t.Run("UpdateMowerCtrl applies change when If-Match is current", func(t *testing.T) {
+		request := NewUpdateMowerRequest("1", UpdateMowerInputDTO{Name: "M-150"})
+		request.Header.Set("If-Match", `"2"`)
+
+		response, _ := newServer(WithRequiredIfMatch()).App.Test(request, -1)
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusOK)
+
+		if got := response.Header.Get("ETag"); got != `"3"` {
+			t.Errorf("got ETag %q want %q", got, `"3"`)
+		}
+	})
+
+	t.Run("UpdateMowerCtrl applies change when If-Match lists the current version", func(t *testing.T) {
+		for _, ifMatch := range []string{`"1", "2"`, `W/"2","2"`, `"2" , "5"`} {
+			request := NewUpdateMowerRequest("1", UpdateMowerInputDTO{Name: "M-150"})
+			request.Header.Set("If-Match", ifMatch)
+
+			response, _ := newServer().App.Test(request, -1)
+
+			lmTesting.AssertStatus(t, response.StatusCode, http.StatusOK)
+		}
+	})
+
+	t.Run("UpdateMowerCtrl return 412 when If-Match is stale", func(t *testing.T) {
+		for _, ifMatch := range []string{`"1"`, `W/"2"`, `2`, `"1", "3"`, `W/"2", 2`} {
+			request := NewUpdateMowerRequest("1", UpdateMowerInputDTO{Name: "M-150"})
+			request.Header.Set("If-Match", ifMatch)
+
+			response, _ := newServer().App.Test(request, -1)
+
+			AssertProblem(t, response, http.StatusPreconditionFailed, "urn:lawn-mower:catalog:version-mismatch")
+		}
+	})
+
+	t.Run("UpdateMowerCtrl return 428 when If-Match is required but missing", func(t *testing.T) {
+		request := NewUpdateMowerRequest("1", UpdateMowerInputDTO{Name: "M-150"})
+
+		response, _ := newServer(WithRequiredIfMatch()).App.Test(request, -1)
+
+		AssertProblem(t, response, http.StatusPreconditionRequired, "about:blank")
+	})
+
+	t.Run("UpdateMowerCtrl accepts any version with If-Match *", func(t *testing.T) {
+		request := NewUpdateMowerRequest("1", UpdateMowerInputDTO{Name: "M-150"})
+		request.Header.Set("If-Match", "*")
+
+		response, _ := newServer(WithRequiredIfMatch()).App.Test(request, -1)
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusOK)
+	})
+}
+
 func TestGetMowerCtrl(t *testing.T) {
 	wantedCatalog := []*domain.Mower{
 		{ID: "1", Name: "M-90"},
@@ -113,7 +252,47 @@ func TestGetMowerCtrl(t *testing.T) {
 
 		response, _ := server.App.Test(request, -1)
 
-		lmTesting.AssertStatus(t, response.StatusCode, http.StatusNotFound)
+		got := AssertProblem(t, response, http.StatusNotFound, "urn:lawn-mower:catalog:not-found")
+
+		want := ProblemDetails{
+			Type:      "urn:lawn-mower:catalog:not-found",
+			Title:     "Resource not found",
+			Status:    http.StatusNotFound,
+			Detail:    domain.MowerNotFound("6").Error(),
+			Instance:  "/mowers/6",
+			RequestID: response.Header.Get(fiber.HeaderXRequestID),
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %+v want %+v", got, want)
+		}
+	})
+}
+
+func TestMalformedIDCtrl(t *testing.T) {
+	repo := &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{}}
+	server, _ := NewCatalogHTTPServer(repo, WithIDGenerator(idgen.UUIDv4{}), WithAdminToken("secret"))
+
+	requests := map[string]*http.Request{
+		"GetMowerCtrl":     NewGetMowerRequest("6"),
+		"UpdateMowerCtrl":  NewUpdateMowerRequest("6", UpdateMowerInputDTO{Name: "M-1"}),
+		"DeleteMowerCtrl":  NewDeleteMowerRequest("6"),
+		"RestoreMowerCtrl": NewRestoreMowerRequest("6"),
+		"PurgeMowerCtrl":   NewPurgeMowerRequest("6", "secret"),
+	}
+
+	for name, request := range requests {
+		t.Run(name+" return 400 on malformed id", func(t *testing.T) {
+			response, _ := server.App.Test(request, -1)
+
+			AssertProblem(t, response, http.StatusBadRequest, "urn:lawn-mower:catalog:malformed-id")
+		})
+	}
+
+	t.Run("GetMowerCtrl return 404 on well-formed missing id", func(t *testing.T) {
+		response, _ := server.App.Test(NewGetMowerRequest(idgen.UUIDv4{}.NewID()), -1)
+
+		AssertProblem(t, response, http.StatusNotFound, "urn:lawn-mower:catalog:not-found")
 	})
 }
 
@@ -137,6 +316,322 @@ func TestGetCatalogCtrl(t *testing.T) {
 		lmTesting.AssertCatalogEquals(t, got, wantedCatalog)
 		lmTesting.AssertStatus(t, response.StatusCode, http.StatusOK)
 		lmTesting.AssertContentType(t, response, JSONContentType)
+
+		if link := response.Header.Get(fiber.HeaderLink); link != "" {
+			t.Errorf("expected a single page without links, got %q", link)
+		}
+	})
+
+	t.Run("GetCatalogCtrl pages through mowers with links", func(t *testing.T) {
+		request := NewGetCatalogRequest()
+		request.URL.RawQuery = "limit=2&total=true"
+
+		response, _ := server.App.Test(request, -1)
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusOK)
+		lmTesting.AssertCatalogEquals(t, lmTesting.GetCatalogFromResponse(t, response.Body), wantedCatalog[:2])
+
+		if total := response.Header.Get(HeaderTotalCount); total != "3" {
+			t.Errorf("got total %q want %q", total, "3")
+		}
+
+		links := parseLinks(response.Header.Get(fiber.HeaderLink))
+
+		if links["prev"] != "" || links["next"] == "" {
+			t.Fatalf("got links %v want only a next link", links)
+		}
+
+		next, _ := url.Parse(links["next"])
+
+		if next.Query().Get("limit") != "2" || next.Query().Get("total") != "true" {
+			t.Errorf("expected next link to keep the query, got %v", next)
+		}
+
+		request = NewGetCatalogRequest()
+		request.URL.RawQuery = next.RawQuery
+
+		response, _ = server.App.Test(request, -1)
+
+		lmTesting.AssertCatalogEquals(t, lmTesting.GetCatalogFromResponse(t, response.Body), wantedCatalog[2:])
+
+		links = parseLinks(response.Header.Get(fiber.HeaderLink))
+
+		if links["prev"] == "" || links["next"] != "" {
+			t.Errorf("got links %v want only a prev link", links)
+		}
+	})
+
+	t.Run("GetCatalogCtrl return 400 on bad paging", func(t *testing.T) {
+		for _, rawQuery := range []string{"limit=0", "limit=1000", "limit=ten", "cursor=not-a-cursor"} {
+			request := NewGetCatalogRequest()
+			request.URL.RawQuery = rawQuery
+
+			response, _ := server.App.Test(request, -1)
+
+			AssertProblem(t, response, http.StatusBadRequest, "urn:lawn-mower:catalog:malformed-query")
+		}
+	})
+}
+
+// parseLinks maps the rel of every link of a Link header to its URL.
+func parseLinks(header string) map[string]string {
+	links := map[string]string{}
+
+	for _, link := range strings.Split(header, ", ") {
+		parts := strings.SplitN(link, "; ", 2)
+
+		if len(parts) == 2 {
+			links[strings.Trim(parts[1], `rel="`)] = strings.Trim(parts[0], "<>")
+		}
+	}
+
+	return links
+}
+
+func TestGetMowersCtrl(t *testing.T) {
+	deletedAt := domain.NewTimestamp(time.Now())
+
+	repo := &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{
+		{ID: "1", Name: "M-90", Specs: domain.MowerSpecs{PowerSource: domain.PowerSourceBattery, CuttingWidthCm: 46, WeightKg: 20}},
+		{ID: "2", Name: "M-150", Specs: domain.MowerSpecs{PowerSource: domain.PowerSourceBattery, CuttingWidthCm: 53, WeightKg: 15}},
+		{ID: "3", Name: "M-480", Specs: domain.MowerSpecs{PowerSource: domain.PowerSourcePetrol, CuttingWidthCm: 53}},
+		{ID: "4", Name: "M-500", Specs: domain.MowerSpecs{PowerSource: domain.PowerSourceBattery, CuttingWidthCm: 51}, DeletedAt: deletedAt},
+	}}
+	stores := &lmTesting.StubStoreRepository{Stores: []*domain.Store{{ID: "1", Name: "Lyon"}}}
+	inventory := &lmTesting.StubInventoryRepository{Units: []*domain.InventoryUnit{
+		{ID: "1", SerialNumber: "SN-1", ModelID: "1", StoreID: "1"},
+	}}
+	server, _ := NewCatalogHTTPServer(repo,
+		WithStoreRepository(stores), WithInventoryRepository(inventory), WithAdminToken("secret"))
+
+	names := func(t *testing.T, response *http.Response) []string {
+		t.Helper()
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusOK)
+
+		got := []string{}
+
+		for _, mower := range lmTesting.GetCatalogFromResponse(t, response.Body) {
+			got = append(got, mower.Name)
+		}
+
+		return got
+	}
+
+	t.Run("GetMowersCtrl filters and sorts mowers", func(t *testing.T) {
+		response, _ := server.App.Test(NewGetMowersRequest("powerSource=battery&cuttingWidthCm[gt]=40&sort=weightKg"), -1)
+
+		if got, want := names(t, response), []string{"M-150", "M-90"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v want %v", got, want)
+		}
+	})
+
+	t.Run("GetMowersCtrl pages through filtered mowers", func(t *testing.T) {
+		response, _ := server.App.Test(NewGetMowersRequest("powerSource=battery&sort=-cuttingWidthCm&limit=1&total=true"), -1)
+
+		if total := response.Header.Get(HeaderTotalCount); total != "2" {
+			t.Errorf("got total %q want %q", total, "2")
+		}
+
+		if got, want := names(t, response), []string{"M-150"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v want %v", got, want)
+		}
+
+		next, _ := url.Parse(parseLinks(response.Header.Get(fiber.HeaderLink))["next"])
+
+		if next.Path != "/mowers" || next.Query().Get("powerSource") != "battery" {
+			t.Fatalf("expected next link to keep the filters, got %v", next)
+		}
+
+		response, _ = server.App.Test(NewGetMowersRequest(next.RawQuery), -1)
+
+		if got, want := names(t, response), []string{"M-90"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v want %v", got, want)
+		}
+
+		if links := parseLinks(response.Header.Get(fiber.HeaderLink)); links["prev"] == "" || links["next"] != "" {
+			t.Errorf("got links %v want only a prev link", links)
+		}
+	})
+
+	t.Run("GetMowersCtrl refuses a cursor of another sort", func(t *testing.T) {
+		response, _ := server.App.Test(NewGetMowersRequest("sort=name&limit=1"), -1)
+		next, _ := url.Parse(parseLinks(response.Header.Get(fiber.HeaderLink))["next"])
+
+		query := next.Query()
+		query.Set("sort", "-name")
+
+		response, _ = server.App.Test(NewGetMowersRequest(query.Encode()), -1)
+
+		AssertProblem(t, response, http.StatusBadRequest, "urn:lawn-mower:catalog:malformed-query")
+	})
+
+	t.Run("GetMowersCtrl keeps the mowers of a store", func(t *testing.T) {
+		response, _ := server.App.Test(NewGetMowersRequest("powerSource=battery&store=1"), -1)
+
+		if got, want := names(t, response), []string{"M-90"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v want %v", got, want)
+		}
+	})
+
+	t.Run("GetMowersCtrl return deleted mowers to admins only", func(t *testing.T) {
+		response, _ := server.App.Test(NewGetMowersRequest("available=false"), -1)
+
+		AssertProblem(t, response, http.StatusForbidden, "about:blank")
+
+		request := NewGetMowersRequest("available=false")
+		request.Header.Set("Authorization", "Bearer secret")
+
+		response, _ = server.App.Test(request, -1)
+
+		if got, want := names(t, response), []string{"M-500"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v want %v", got, want)
+		}
+	})
+
+	t.Run("GetMowersCtrl return 400 with the allowed values", func(t *testing.T) {
+		cases := []struct {
+			rawQuery string
+			allowed  []string
+		}{
+			{"price[lt]=300", domain.MowerFilterFields()},
+			{"powerSource[gt]=battery", []string{"eq", "ne"}},
+			{"powerSource=diesel", []string{"petrol", "electric", "battery", "robotic"}},
+			{"sort=-price", domain.MowerSortFields()},
+			{"available=maybe", []string{"true", "false", "any"}},
+			{"includeDeleted=1", []string{"true", "false"}},
+			{"includeDeleted=TRUE", []string{"true", "false"}},
+		}
+
+		for _, c := range cases {
+			response, _ := server.App.Test(NewGetMowersRequest(c.rawQuery), -1)
+
+			problem := AssertProblem(t, response, http.StatusBadRequest, "urn:lawn-mower:catalog:malformed-query")
+
+			if !reflect.DeepEqual(problem.Allowed, c.allowed) {
+				t.Errorf("%s: got allowed %v want %v", c.rawQuery, problem.Allowed, c.allowed)
+			}
+		}
+	})
+
+	t.Run("GetMowersCtrl return 400 on includeDeleted with available", func(t *testing.T) {
+		for _, rawQuery := range []string{"includeDeleted=true&available=true", "available=true&includeDeleted=true"} {
+			request := NewGetMowersRequest(rawQuery)
+			request.Header.Set("Authorization", "Bearer secret")
+
+			response, _ := server.App.Test(request, -1)
+
+			AssertProblem(t, response, http.StatusBadRequest, "urn:lawn-mower:catalog:malformed-query")
+		}
+	})
+
+	t.Run("GetMowersCtrl return 400 on values of the wrong type", func(t *testing.T) {
+		response, _ := server.App.Test(NewGetMowersRequest("cuttingWidthCm[gte]=wide"), -1)
+
+		AssertProblem(t, response, http.StatusBadRequest, "urn:lawn-mower:catalog:malformed-query")
+	})
+}
+
+func TestDeleteMowerCtrl(t *testing.T) {
+	t.Run("DeleteMowerCtrl soft deletes then restores a mower", func(t *testing.T) {
+		clock := lmTesting.NewFakeClock(testNow)
+		clock.Step = time.Minute
+
+		repo := &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{
+			{ID: "1", Name: "M-90"},
+			{ID: "2", Name: "M-150"},
+		}, Clock: clock}
+		server, _ := NewCatalogHTTPServer(repo)
+
+		response, _ := server.App.Test(NewDeleteMowerRequest("1"), -1)
+
+		got := lmTesting.GetMowerFromResponse(t, response.Body)
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusOK)
+
+		if got.DeletedAt == nil || !time.Time(*got.DeletedAt).Equal(testNow) {
+			t.Errorf("expected deletedAt %v in response, got %v", testNow, got.DeletedAt)
+		}
+
+		response, _ = server.App.Test(NewGetCatalogRequest(), -1)
+
+		lmTesting.AssertCatalogEquals(t, lmTesting.GetCatalogFromResponse(t, response.Body), []*domain.Mower{{ID: "2", Name: "M-150"}})
+
+		response, _ = server.App.Test(NewRestoreMowerRequest("1"), -1)
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusOK)
+		lmTesting.AssertMowerEquals(t, lmTesting.GetMowerFromResponse(t, response.Body), domain.Mower{
+			ID:        "1",
+			Name:      "M-90",
+			UpdatedAt: domain.NewTimestamp(testNow.Add(time.Minute)),
+			Version:   2,
+		})
+	})
+
+	t.Run("DeleteMowerCtrl return 404 on missing mower", func(t *testing.T) {
+		repo := &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{}}
+		server, _ := NewCatalogHTTPServer(repo)
+
+		response, _ := server.App.Test(NewDeleteMowerRequest("6"), -1)
+
+		AssertProblem(t, response, http.StatusNotFound, "urn:lawn-mower:catalog:not-found")
+	})
+}
+
+func TestAdminCtrl(t *testing.T) {
+	newServer := func() *CatalogHTTPServer {
+		repo := &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{
+			{ID: "1", Name: "M-90"},
+			{ID: "2", Name: "M-150"},
+		}}
+		server, _ := NewCatalogHTTPServer(repo, WithAdminToken("secret"))
+		server.App.Test(NewDeleteMowerRequest("1"), -1)
+
+		return server
+	}
+
+	t.Run("GetCatalogCtrl lists deleted mowers for admins", func(t *testing.T) {
+		server := newServer()
+
+		request := NewGetCatalogRequest()
+		request.URL.RawQuery = "includeDeleted=true"
+		request.Header.Set("Authorization", "Bearer secret")
+
+		response, _ := server.App.Test(request, -1)
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusOK)
+
+		if got := lmTesting.GetCatalogFromResponse(t, response.Body); len(got) != 2 {
+			t.Errorf("expected deleted mower in catalog, got %v", got)
+		}
+	})
+
+	t.Run("GetCatalogCtrl forbids includeDeleted without admin token", func(t *testing.T) {
+		server := newServer()
+
+		request := NewGetCatalogRequest()
+		request.URL.RawQuery = "includeDeleted=true"
+		request.Header.Set("Authorization", "Bearer wrong")
+
+		response, _ := server.App.Test(request, -1)
+
+		AssertProblem(t, response, http.StatusForbidden, "about:blank")
+	})
+
+	t.Run("PurgeMowerCtrl requires admin token", func(t *testing.T) {
+		server := newServer()
+
+		response, _ := server.App.Test(NewPurgeMowerRequest("1", ""), -1)
+
+		AssertProblem(t, response, http.StatusForbidden, "about:blank")
+
+		response, _ = server.App.Test(NewPurgeMowerRequest("1", "secret"), -1)
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusNoContent)
+
+		response, _ = server.App.Test(NewPurgeMowerRequest("1", "secret"), -1)
+
+		AssertProblem(t, response, http.StatusNotFound, "urn:lawn-mower:catalog:not-found")
 	})
 }
 
@@ -169,3 +664,57 @@ func NewGetCatalogRequest() *http.Request {
 	req.Header.Set("Content-Type", "application/json")
 	return req
 }
+
+func NewGetMowersRequest(rawQuery string) *http.Request {
+	req, _ := http.NewRequest(http.MethodGet, "/mowers?"+rawQuery, nil)
+	return req
+}
+
+func NewDeleteMowerRequest(ID string) *http.Request {
+	req, _ := http.NewRequest(http.MethodDelete, "/mowers/"+ID, nil)
+	return req
+}
+
+func NewRestoreMowerRequest(ID string) *http.Request {
+	req, _ := http.NewRequest(http.MethodPost, "/mowers/"+ID+"/restore", nil)
+	return req
+}
+
+func NewPurgeMowerRequest(ID, adminToken string) *http.Request {
+	req, _ := http.NewRequest(http.MethodDelete, "/admin/mowers/"+ID, nil)
+
+	if adminToken != "" {
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+	}
+
+	return req
+}
+
+// AssertProblem checks that response is an RFC 7807 problem of the given
+// status and type, tied to the request id, and returns it.
+func AssertProblem(t testing.TB, response *http.Response, status int, problemType string) ProblemDetails {
+	t.Helper()
+
+	lmTesting.AssertStatus(t, response.StatusCode, status)
+	lmTesting.AssertContentType(t, response, ProblemContentType)
+
+	var problem ProblemDetails
+
+	if err := json.NewDecoder(response.Body).Decode(&problem); err != nil {
+		t.Fatalf("Unable to parse response from server into ProblemDetails, '%v'", err)
+	}
+
+	if problem.Status != status || problem.Type != problemType || problem.Title == "" {
+		t.Errorf("got problem %+v want status %d and type %s", problem, status, problemType)
+	}
+
+	if problem.RequestID == "" || problem.RequestID != response.Header.Get(fiber.HeaderXRequestID) {
+		t.Errorf("got request id %q want the X-Request-ID header %q", problem.RequestID, response.Header.Get(fiber.HeaderXRequestID))
+	}
+
+	if problem.Instance == "" {
+		t.Errorf("expected instance to be set")
+	}
+
+	return problem
+}
@@ -0,0 +1,156 @@
+package restcontroller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"log"
+	"net/http"
+	"reflect"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var ProblemContentType = "application/problem+json"
+
+// ProblemDetails is the RFC 7807 body of every error answer. RequestID
+// repeats the X-Request-ID header, Errors lists the invalid fields of a
+// 422 Unprocessable Entity and Allowed the values a malformed query
+// parameter accepts.
+type ProblemDetails struct {
+	Type      string              `json:"type"`
+	Title     string              `json:"title"`
+	Status    int                 `json:"status"`
+	Detail    string              `json:"detail,omitempty"`
+	Instance  string              `json:"instance,omitempty"`
+	RequestID string              `json:"requestId,omitempty"`
+	Errors    []domain.FieldError `json:"errors,omitempty"`
+	Allowed   []string            `json:"allowed,omitempty"`
+}
+
+// problemType describes the problems a domain error kind, or the deadline
+// of a request, leads to.
+type problemType struct {
+	kind   error
+	uri    string
+	title  string
+	status int
+}
+
+// problemTypes is checked in order, the specific errors coming before the
+// kind they belong to.
+var problemTypes = []problemType{
+	{domain.ErrVersionConflict, "urn:lawn-mower:catalog:version-mismatch", "Version mismatch", http.StatusPreconditionFailed},
+	{domain.ErrMalformedID, "urn:lawn-mower:catalog:malformed-id", "Malformed id", http.StatusBadRequest},
+	{domain.ErrMalformedQuery, "urn:lawn-mower:catalog:malformed-query", "Malformed query", http.StatusBadRequest},
+	{domain.ErrNotFound, "urn:lawn-mower:catalog:not-found", "Resource not found", http.StatusNotFound},
+	{domain.ErrConflict, "urn:lawn-mower:catalog:conflict", "Conflict", http.StatusConflict},
+	{domain.ErrValidation, "urn:lawn-mower:catalog:validation-failed", "Validation failed", http.StatusUnprocessableEntity},
+	{domain.ErrUnavailable, "urn:lawn-mower:catalog:unavailable", "Service unavailable", http.StatusServiceUnavailable},
+	{context.DeadlineExceeded, "urn:lawn-mower:catalog:timeout", "Request timed out", http.StatusGatewayTimeout},
+}
+
+// errorHandler answers every error returned by a handler as problem+json,
+// domain errors with the status of their kind and anything unexpected with a
+// bare 500.
+func errorHandler(c *fiber.Ctx, err error) error {
+	problem := newProblem(err)
+
+	problem.Instance = c.OriginalURL()
+	problem.RequestID = c.GetRespHeader(fiber.HeaderXRequestID)
+
+	if problem.Status == http.StatusInternalServerError {
+		log.Printf("request %s %s %s failed: %v", problem.RequestID, c.Method(), c.Path(), err)
+	}
+
+	body, err := json.Marshal(problem)
+
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, ProblemContentType)
+
+	return c.Status(problem.Status).Send(body)
+}
+
+func newProblem(err error) ProblemDetails {
+	var fiberErr *fiber.Error
+
+	if errors.As(err, &fiberErr) {
+		return ProblemDetails{
+			Type:   "about:blank",
+			Title:  http.StatusText(fiberErr.Code),
+			Status: fiberErr.Code,
+			Detail: fiberErr.Message,
+		}
+	}
+
+	for _, t := range problemTypes {
+		if errors.Is(err, t.kind) {
+			problem := ProblemDetails{Type: t.uri, Title: t.title, Status: t.status, Detail: err.Error()}
+
+			var validationErr *domain.ValidationError
+
+			if errors.As(err, &validationErr) {
+				problem.Errors = validationErr.Fields
+			}
+
+			var queryErr *domain.QueryError
+
+			if errors.As(err, &queryErr) {
+				problem.Allowed = queryErr.Allowed
+			}
+
+			return problem
+		}
+	}
+
+	return ProblemDetails{
+		Type:   "about:blank",
+		Title:  http.StatusText(http.StatusInternalServerError),
+		Status: http.StatusInternalServerError,
+	}
+}
+
+// parseBody decodes the request body into out. JSON values of the wrong type
+// are reported as a *domain.ValidationError on the offending field, other
+// decoding failures as 400 Bad Request.
+func parseBody(c *fiber.Ctx, out interface{}) error {
+	err := c.BodyParser(out)
+
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+
+	switch {
+	case errors.As(err, &typeErr):
+		validationErr := new(domain.ValidationError)
+		validationErr.Add(typeErr.Field, "must be "+jsonType(typeErr.Type))
+
+		return validationErr
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return fiber.NewError(http.StatusBadRequest, "request body is not valid JSON")
+	}
+
+	return err
+}
+
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	}
+
+	return "an object"
+}
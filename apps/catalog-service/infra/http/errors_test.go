@@ -0,0 +1,79 @@
+package restcontroller
+
+import (
+	"context"
+	"errors"
+	lmTesting "jrobic/lawn-mower/catalog-service"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type failingRepository struct {
+	lmTesting.StubCatalogRepository
+}
+
+func (r *failingRepository) FindMowerPage(ctx context.Context, query domain.PageQuery) (*domain.MowerPage, error) {
+	return nil, errors.New("pq: connection refused to 10.0.0.12:5432")
+}
+
+func TestErrorHandlerCtrl(t *testing.T) {
+	t.Run("UpdateMowerCtrl return 404 on unknown mower", func(t *testing.T) {
+		server, _ := NewCatalogHTTPServer(&lmTesting.StubCatalogRepository{})
+
+		response, _ := server.App.Test(NewUpdateMowerRequest("6", UpdateMowerInputDTO{Name: "M-90"}), -1)
+
+		got := AssertProblem(t, response, http.StatusNotFound, "urn:lawn-mower:catalog:not-found")
+
+		if got.Detail != domain.MowerNotFound("6").Error() {
+			t.Errorf("got %q want %q", got.Detail, domain.MowerNotFound("6").Error())
+		}
+	})
+
+	t.Run("unexpected errors return 500 without details", func(t *testing.T) {
+		server, _ := NewCatalogHTTPServer(&failingRepository{})
+
+		response, _ := server.App.Test(NewGetCatalogRequest(), -1)
+
+		got := AssertProblem(t, response, http.StatusInternalServerError, "about:blank")
+
+		if got.Title != "Internal Server Error" || got.Detail != "" {
+			t.Errorf("got %+v want a bare Internal Server Error", got)
+		}
+	})
+
+	t.Run("unknown routes return 404", func(t *testing.T) {
+		server, _ := NewCatalogHTTPServer(&lmTesting.StubCatalogRepository{})
+
+		request, _ := http.NewRequest(http.MethodGet, "/lawns", nil)
+		response, _ := server.App.Test(request, -1)
+
+		AssertProblem(t, response, http.StatusNotFound, "about:blank")
+	})
+
+	t.Run("requests past their deadline return 504", func(t *testing.T) {
+		repo := &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{{ID: "1", Name: "M-90"}}, Delay: time.Second}
+		server, _ := NewCatalogHTTPServer(repo, WithRequestTimeouts(20*time.Millisecond, time.Minute))
+
+		start := time.Now()
+		response, _ := server.App.Test(NewGetMowerRequest("1"), -1)
+
+		AssertProblem(t, response, http.StatusGatewayTimeout, "urn:lawn-mower:catalog:timeout")
+
+		if elapsed := time.Since(start); elapsed >= repo.Delay {
+			t.Errorf("took %v, the repository call should have been cancelled", elapsed)
+		}
+	})
+
+	t.Run("listings have a deadline of their own", func(t *testing.T) {
+		repo := &lmTesting.StubCatalogRepository{Delay: 50 * time.Millisecond}
+		server, _ := NewCatalogHTTPServer(repo, WithRequestTimeouts(time.Millisecond, time.Minute))
+
+		response, _ := server.App.Test(NewGetCatalogRequest(), -1)
+
+		if response.StatusCode != http.StatusOK {
+			t.Errorf("got status %d want %d", response.StatusCode, http.StatusOK)
+		}
+	})
+}
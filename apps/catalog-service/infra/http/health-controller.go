@@ -0,0 +1,32 @@
+package restcontroller
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Liveness answers 200 OK as long as the process serves requests. It checks
+// no dependency, so that a database outage never gets the service restarted.
+func (serv *CatalogHTTPServer) Liveness(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "no-store")
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{"status": "up"})
+}
+
+// Readiness runs the registered health checks and answers their report, with
+// 200 OK when they all pass and 503 Service Unavailable when one fails or the
+// server is draining.
+func (serv *CatalogHTTPServer) Readiness(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "no-store")
+
+	report := serv.health.Check(c.UserContext())
+
+	status := http.StatusOK
+
+	if !report.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+
+	return c.Status(status).JSON(report)
+}
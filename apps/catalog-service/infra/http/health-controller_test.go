@@ -0,0 +1,99 @@
+package restcontroller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	lmTesting "jrobic/lawn-mower/catalog-service"
+	"jrobic/lawn-mower/catalog-service/infra/healthcheck"
+	"net/http"
+	"testing"
+)
+
+func TestHealthCtrl(t *testing.T) {
+	decodeReport := func(t *testing.T, response *http.Response) healthcheck.Report {
+		t.Helper()
+
+		var report healthcheck.Report
+
+		if err := json.NewDecoder(response.Body).Decode(&report); err != nil {
+			t.Fatalf("Unable to parse response from server into report, '%v'", err)
+		}
+
+		return report
+	}
+
+	t.Run("Liveness ignores the dependencies", func(t *testing.T) {
+		checker := healthcheck.NewHealthChecker()
+		checker.Register("postgres", 0, func(ctx context.Context) error {
+			return errors.New("connection refused")
+		})
+
+		server, _ := NewCatalogHTTPServer(&lmTesting.StubCatalogRepository{}, WithHealthChecker(checker))
+
+		response, _ := server.App.Test(NewHealthRequest("/healthz"), -1)
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusOK)
+		lmTesting.AssertContentType(t, response, JSONContentType)
+	})
+
+	t.Run("Readiness without checks", func(t *testing.T) {
+		server, _ := NewCatalogHTTPServer(&lmTesting.StubCatalogRepository{})
+
+		response, _ := server.App.Test(NewHealthRequest("/readyz"), -1)
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusOK)
+
+		if report := decodeReport(t, response); report.Status != healthcheck.StatusUp {
+			t.Errorf("got %v want up", report)
+		}
+	})
+
+	t.Run("Readiness reports every component", func(t *testing.T) {
+		checker := healthcheck.NewHealthChecker()
+		checker.Register("postgres", 0, func(ctx context.Context) error {
+			return errors.New("connection refused")
+		})
+		checker.Register("cache", 0, func(ctx context.Context) error {
+			return nil
+		})
+
+		server, _ := NewCatalogHTTPServer(&lmTesting.StubCatalogRepository{}, WithHealthChecker(checker))
+
+		response, _ := server.App.Test(NewHealthRequest("/readyz"), -1)
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusServiceUnavailable)
+
+		report := decodeReport(t, response)
+
+		if report.Status != healthcheck.StatusDown ||
+			report.Components["postgres"].Error != "connection refused" ||
+			report.Components["cache"].Status != healthcheck.StatusUp {
+			t.Errorf("got %+v want postgres down and cache up", report)
+		}
+	})
+
+	t.Run("Readiness fails while draining", func(t *testing.T) {
+		checker := healthcheck.NewHealthChecker()
+		server, _ := NewCatalogHTTPServer(&lmTesting.StubCatalogRepository{}, WithHealthChecker(checker))
+
+		checker.Drain()
+
+		response, _ := server.App.Test(NewHealthRequest("/readyz"), -1)
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusServiceUnavailable)
+
+		if report := decodeReport(t, response); !report.Draining {
+			t.Errorf("got %+v want draining", report)
+		}
+
+		response, _ = server.App.Test(NewHealthRequest("/healthz"), -1)
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusOK)
+	})
+}
+
+func NewHealthRequest(path string) *http.Request {
+	req, _ := http.NewRequest(http.MethodGet, path, nil)
+	return req
+}
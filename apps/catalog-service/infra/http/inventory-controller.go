@@ -0,0 +1,96 @@
+package restcontroller
+
+import (
+	"jrobic/lawn-mower/catalog-service/domain"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type RegisterUnitInputDTO struct {
+	SerialNumber string `json:"ns"`
+	ModelID      string `json:"model"`
+}
+
+type MoveUnitInputDTO struct {
+	StoreID string `json:"store"`
+}
+
+func (serv *CatalogHTTPServer) RegisterUnit(c *fiber.Ctx) error {
+	c.Append("content-type", JSONContentType)
+
+	unitToRegister := new(RegisterUnitInputDTO)
+
+	err := parseBody(c, unitToRegister)
+
+	if err != nil {
+		return err
+	}
+
+	unit, err := serv.service.RegisterUnit(c.UserContext(), c.Params("id"), domain.RegisterUnitDTO{
+		SerialNumber: unitToRegister.SerialNumber,
+		ModelID:      unitToRegister.ModelID,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusAccepted).JSON(unit)
+}
+
+func (serv *CatalogHTTPServer) RetireUnit(c *fiber.Ctx) error {
+	c.Append("content-type", JSONContentType)
+
+	unit, err := serv.service.RetireUnit(c.UserContext(), c.Params("id"), c.Params("unitId"))
+
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(unit)
+}
+
+func (serv *CatalogHTTPServer) MoveUnit(c *fiber.Ctx) error {
+	c.Append("content-type", JSONContentType)
+
+	move := new(MoveUnitInputDTO)
+
+	err := parseBody(c, move)
+
+	if err != nil {
+		return err
+	}
+
+	unit, err := serv.service.MoveUnit(c.UserContext(), c.Params("id"), c.Params("unitId"), move.StoreID)
+
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(unit)
+}
+
+func (serv *CatalogHTTPServer) GetStoreInventory(c *fiber.Ctx) error {
+	c.Append("content-type", JSONContentType)
+
+	units, err := serv.service.GetStoreInventory(c.UserContext(), c.Params("id"))
+
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(units)
+}
+
+func (serv *CatalogHTTPServer) GetMowerInventory(c *fiber.Ctx) error {
+	c.Append("content-type", JSONContentType)
+
+	units, err := serv.service.GetModelInventory(c.UserContext(), c.Params("id"))
+
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(units)
+}
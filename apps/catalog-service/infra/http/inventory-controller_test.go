@@ -0,0 +1,144 @@
+package restcontroller
+
+import (
+	"bytes"
+	"encoding/json"
+	lmTesting "jrobic/lawn-mower/catalog-service"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"net/http"
+	"testing"
+)
+
+func TestInventoryCtrl(t *testing.T) {
+	newServer := func() *CatalogHTTPServer {
+		repo := &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{
+			{ID: "1", Name: "M-90"},
+			{ID: "2", Name: "M-150"},
+		}}
+		stores := &lmTesting.StubStoreRepository{Stores: []*domain.Store{
+			{ID: "1", Name: "Lyon"},
+			{ID: "2", Name: "Paris"},
+		}}
+		inventory := &lmTesting.StubInventoryRepository{Units: []*domain.InventoryUnit{
+			{ID: "1", SerialNumber: "SN-1", ModelID: "2", StoreID: "1"},
+		}}
+		repo.Inventory = inventory
+		server, _ := NewCatalogHTTPServer(repo, WithStoreRepository(stores), WithInventoryRepository(inventory))
+
+		return server
+	}
+
+	t.Run("RegisterUnitCtrl return accepted on POST", func(t *testing.T) {
+		response, _ := newServer().App.Test(NewRegisterUnitRequest("1", RegisterUnitInputDTO{SerialNumber: "SN-2", ModelID: "1"}), -1)
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusAccepted)
+		lmTesting.AssertContentType(t, response, JSONContentType)
+
+		got := lmTesting.GetUnitFromResponse(t, response.Body)
+
+		if got != (domain.InventoryUnit{ID: "2", SerialNumber: "SN-2", ModelID: "1", StoreID: "1"}) {
+			t.Errorf("got %v want unit SN-2 of model #1 at store #1", got)
+		}
+	})
+
+	t.Run("RegisterUnitCtrl return 409 on taken serial number", func(t *testing.T) {
+		response, _ := newServer().App.Test(NewRegisterUnitRequest("2", RegisterUnitInputDTO{SerialNumber: "SN-1", ModelID: "1"}), -1)
+
+		AssertProblem(t, response, http.StatusConflict, "urn:lawn-mower:catalog:conflict")
+	})
+
+	t.Run("RegisterUnitCtrl return 422 on missing serial number", func(t *testing.T) {
+		response, _ := newServer().App.Test(NewRegisterUnitRequest("1", RegisterUnitInputDTO{ModelID: "1"}), -1)
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("GetStoreInventoryCtrl return store units", func(t *testing.T) {
+		response, _ := newServer().App.Test(NewGetStoreInventoryRequest("1"), -1)
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusOK)
+
+		if got := lmTesting.GetInventoryFromResponse(t, response.Body); len(got) != 1 || got[0].SerialNumber != "SN-1" {
+			t.Errorf("got %v want unit SN-1", got)
+		}
+	})
+
+	t.Run("MoveUnitCtrl move unit to another store", func(t *testing.T) {
+		server := newServer()
+
+		response, _ := server.App.Test(NewMoveUnitRequest("1", "1", MoveUnitInputDTO{StoreID: "2"}), -1)
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusOK)
+
+		if got := lmTesting.GetUnitFromResponse(t, response.Body); got.StoreID != "2" {
+			t.Errorf("got %v want unit at store #2", got)
+		}
+
+		response, _ = server.App.Test(NewMoveUnitRequest("1", "1", MoveUnitInputDTO{StoreID: "2"}), -1)
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusNotFound)
+	})
+
+	t.Run("RetireUnitCtrl retire unit on DELETE", func(t *testing.T) {
+		server := newServer()
+
+		response, _ := server.App.Test(NewRetireUnitRequest("1", "1"), -1)
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusOK)
+
+		response, _ = server.App.Test(NewGetMowerInventoryRequest("2"), -1)
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusOK)
+
+		if got := lmTesting.GetInventoryFromResponse(t, response.Body); len(got) != 0 {
+			t.Errorf("got %v want no unit", got)
+		}
+	})
+
+	t.Run("DeleteMowerCtrl return 409 while model has units", func(t *testing.T) {
+		response, _ := newServer().App.Test(NewDeleteMowerRequest("2"), -1)
+
+		AssertProblem(t, response, http.StatusConflict, "urn:lawn-mower:catalog:conflict")
+	})
+
+	t.Run("inventory routes return 503 without inventory repository", func(t *testing.T) {
+		server, _ := NewCatalogHTTPServer(&lmTesting.StubCatalogRepository{}, WithStoreRepository(&lmTesting.StubStoreRepository{}))
+
+		response, _ := server.App.Test(NewGetStoreInventoryRequest("1"), -1)
+
+		AssertProblem(t, response, http.StatusServiceUnavailable, "urn:lawn-mower:catalog:unavailable")
+	})
+}
+
+func NewRegisterUnitRequest(storeID string, body interface{}) *http.Request {
+	jsonBytes, _ := json.Marshal(body)
+
+	req, _ := http.NewRequest(http.MethodPost, "/stores/"+storeID+"/inventory", bytes.NewReader(jsonBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	return req
+}
+
+func NewMoveUnitRequest(storeID, unitID string, body interface{}) *http.Request {
+	jsonBytes, _ := json.Marshal(body)
+
+	req, _ := http.NewRequest(http.MethodPost, "/stores/"+storeID+"/inventory/"+unitID+"/move", bytes.NewReader(jsonBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	return req
+}
+
+func NewRetireUnitRequest(storeID, unitID string) *http.Request {
+	req, _ := http.NewRequest(http.MethodDelete, "/stores/"+storeID+"/inventory/"+unitID, nil)
+	return req
+}
+
+func NewGetStoreInventoryRequest(storeID string) *http.Request {
+	req, _ := http.NewRequest(http.MethodGet, "/stores/"+storeID+"/inventory", nil)
+	return req
+}
+
+func NewGetMowerInventoryRequest(ID string) *http.Request {
+	req, _ := http.NewRequest(http.MethodGet, "/mowers/"+ID+"/inventory", nil)
+	return req
+}
@@ -0,0 +1,48 @@
+package restcontroller
+
+import (
+	"fmt"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var HeaderTotalCount = "X-Total-Count"
+
+// setPageHeaders links the neighbour pages, keeping every other query
+// parameter of the request, and exposes the total count when known.
+func setPageHeaders(c *fiber.Ctx, page *domain.MowerPage, limit int) {
+	links := []string{}
+
+	if page.Prev != nil {
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, pageURL(c, page.Prev, limit)))
+	}
+
+	if page.Next != nil {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, pageURL(c, page.Next, limit)))
+	}
+
+	if len(links) > 0 {
+		c.Set(fiber.HeaderLink, strings.Join(links, ", "))
+	}
+
+	if page.Total != nil {
+		c.Set(HeaderTotalCount, strconv.Itoa(*page.Total))
+	}
+}
+
+func pageURL(c *fiber.Ctx, cursor *domain.Cursor, limit int) string {
+	query := url.Values{}
+
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		query.Add(string(key), string(value))
+	})
+
+	query.Set("cursor", cursor.Encode())
+	query.Set("limit", strconv.Itoa(limit))
+
+	return c.BaseURL() + c.Path() + "?" + query.Encode()
+}
@@ -0,0 +1,92 @@
+package restcontroller
+
+import (
+	"jrobic/lawn-mower/catalog-service/domain"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var availabilities = map[string]domain.Availability{
+	"true":  domain.OnlyAvailable,
+	"false": domain.OnlyDeleted,
+	"any":   domain.AnyAvailability,
+}
+
+// parseMowerQuery reads the criteria and the page of GET /mowers. Every
+// parameter other than `sort`, `store`, `available`, `includeDeleted`,
+// `limit`, `cursor` and `total` filters on a field, `field=value` or
+// `field[op]=value`. `includeDeleted=true` is the older spelling of
+// `available=any`, so the two cannot be combined.
+func (serv *CatalogHTTPServer) parseMowerQuery(c *fiber.Ctx) (domain.PageQuery, error) {
+	query := domain.PageQuery{}
+	var err error
+	var availability, includeDeleted bool
+
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		if err != nil {
+			return
+		}
+
+		param, raw := string(key), string(value)
+
+		switch param {
+		case "sort":
+			query.Sort, err = domain.ParseSort(raw)
+		case "store":
+			if serv.ids != nil && !serv.ids.Valid(raw) {
+				err = domain.MalformedID(raw)
+			}
+
+			query.StoreID = raw
+		case "available":
+			availability = true
+			available, ok := availabilities[raw]
+
+			if !ok {
+				err = &domain.QueryError{Param: param, Reason: "must be a known availability", Allowed: []string{"true", "false", "any"}}
+			}
+
+			query.Availability = available
+		case "includeDeleted":
+			includeDeleted = true
+
+			switch raw {
+			case "true":
+				query.Availability = domain.AnyAvailability
+			case "false":
+				// the default, live mowers only
+			default:
+				err = &domain.QueryError{Param: param, Reason: "must be a boolean", Allowed: []string{"true", "false"}}
+			}
+		case "limit":
+			query.Limit, err = strconv.Atoi(raw)
+
+			if err != nil || query.Limit < 1 {
+				err = domain.InvalidQuery("limit must be a positive integer")
+			}
+		case "cursor":
+			query.Cursor, err = domain.DecodeCursor(raw)
+		case "total":
+			query.CountTotal = raw == "true"
+		default:
+			var filter domain.MowerFilter
+
+			field, op := param, domain.OpEq
+
+			if open := strings.IndexByte(param, '['); open > 0 && strings.HasSuffix(param, "]") {
+				field, op = param[:open], domain.Operator(param[open+1:len(param)-1])
+			}
+
+			filter, err = domain.NewMowerFilter(field, op, raw)
+			query.Filters = append(query.Filters, filter)
+		}
+	})
+
+	if err == nil && availability && includeDeleted {
+		err = &domain.QueryError{Param: "includeDeleted", Reason: "cannot be combined with `available`"}
+	}
+
+	return query, err
+}
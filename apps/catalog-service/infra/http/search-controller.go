@@ -0,0 +1,64 @@
+package restcontroller
+
+import (
+	"jrobic/lawn-mower/catalog-service/domain"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SearchMowers answers the mowers matching `q`, best matches first, along
+// with their score.
+func (serv *CatalogHTTPServer) SearchMowers(c *fiber.Ctx) error {
+	c.Append("content-type", JSONContentType)
+
+	limit, err := parseSearchLimit(c)
+
+	if err != nil {
+		return err
+	}
+
+	hits, err := serv.service.SearchMowers(c.UserContext(), c.Query("q"), limit)
+
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(hits)
+}
+
+// SuggestMowers answers the names of the mowers completing `prefix`.
+func (serv *CatalogHTTPServer) SuggestMowers(c *fiber.Ctx) error {
+	c.Append("content-type", JSONContentType)
+
+	limit, err := parseSearchLimit(c)
+
+	if err != nil {
+		return err
+	}
+
+	names, err := serv.service.SuggestMowers(c.UserContext(), c.Query("prefix"), limit)
+
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(names)
+}
+
+func parseSearchLimit(c *fiber.Ctx) (int, error) {
+	limit := c.Query("limit")
+
+	if limit == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(limit)
+
+	if err != nil || n < 1 {
+		return 0, domain.InvalidQuery("limit must be a positive integer")
+	}
+
+	return n, nil
+}
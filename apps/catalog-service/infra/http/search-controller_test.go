@@ -0,0 +1,90 @@
+package restcontroller
+
+import (
+	"encoding/json"
+	lmTesting "jrobic/lawn-mower/catalog-service"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"jrobic/lawn-mower/catalog-service/infra/search"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestSearchCtrl(t *testing.T) {
+	newServer := func(t *testing.T) *CatalogHTTPServer {
+		server, _ := NewCatalogHTTPServer(&lmTesting.StubCatalogRepository{}, WithSearchIndex(search.NewInvertedIndex()))
+
+		for _, name := range []string{"M-90", "M-150", "M-480"} {
+			response, _ := server.App.Test(NewCreateMowerRequest(CreateMowerInputDTO{Name: name}), -1)
+			lmTesting.AssertStatus(t, response.StatusCode, http.StatusAccepted)
+		}
+
+		return server
+	}
+
+	t.Run("SearchMowersCtrl return ranked hits", func(t *testing.T) {
+		response, _ := newServer(t).App.Test(NewSearchMowersRequest("q", "M-4"), -1)
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusOK)
+		lmTesting.AssertContentType(t, response, JSONContentType)
+
+		var hits []domain.SearchHit
+
+		if err := json.NewDecoder(response.Body).Decode(&hits); err != nil {
+			t.Fatalf("Unable to parse response from server into hits, '%v'", err)
+		}
+
+		if len(hits) != 1 || hits[0].Mower.Name != "M-480" || hits[0].Score <= 0 {
+			t.Errorf("got %v want M-480", hits)
+		}
+	})
+
+	t.Run("SuggestMowersCtrl return names", func(t *testing.T) {
+		server := newServer(t)
+
+		response, _ := server.App.Test(NewDeleteMowerRequest("2"), -1)
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusOK)
+
+		response, _ = server.App.Test(NewSearchMowersRequest("prefix", "m"), -1)
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusOK)
+
+		var names []string
+
+		if err := json.NewDecoder(response.Body).Decode(&names); err != nil {
+			t.Fatalf("Unable to parse response from server into names, '%v'", err)
+		}
+
+		if want := []string{"M-480", "M-90"}; !reflect.DeepEqual(names, want) {
+			t.Errorf("got %v want %v", names, want)
+		}
+	})
+
+	t.Run("SearchMowersCtrl return 400 without text", func(t *testing.T) {
+		response, _ := newServer(t).App.Test(NewSearchMowersRequest("q", ""), -1)
+
+		AssertProblem(t, response, http.StatusBadRequest, "urn:lawn-mower:catalog:malformed-query")
+	})
+
+	t.Run("SearchMowersCtrl return 503 without index", func(t *testing.T) {
+		server, _ := NewCatalogHTTPServer(&lmTesting.StubCatalogRepository{})
+
+		response, _ := server.App.Test(NewSearchMowersRequest("q", "M"), -1)
+
+		AssertProblem(t, response, http.StatusServiceUnavailable, "urn:lawn-mower:catalog:unavailable")
+	})
+}
+
+// NewSearchMowersRequest asks /mowers/search for `q` and /mowers/suggest
+// for `prefix`.
+func NewSearchMowersRequest(param, text string) *http.Request {
+	path := "/mowers/search?"
+
+	if param == "prefix" {
+		path = "/mowers/suggest?"
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, path+url.Values{param: {text}}.Encode(), nil)
+	return req
+}
@@ -0,0 +1,80 @@
+package restcontroller
+
+import (
+	"jrobic/lawn-mower/catalog-service/domain"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CreateStoreInputDTO struct {
+	Name string `json:"name"`
+}
+
+type UpdateStoreInputDTO struct {
+	Name string `json:"name,omitempty"`
+}
+
+func (serv *CatalogHTTPServer) CreateStore(c *fiber.Ctx) error {
+	c.Append("content-type", JSONContentType)
+
+	storeToCreate := new(CreateStoreInputDTO)
+
+	err := parseBody(c, storeToCreate)
+
+	if err != nil {
+		return err
+	}
+
+	store, err := serv.service.CreateStore(c.UserContext(), domain.CreateStoreDTO{Name: storeToCreate.Name})
+
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusAccepted).JSON(store)
+}
+
+func (serv *CatalogHTTPServer) GetStore(c *fiber.Ctx) error {
+	c.Append("content-type", JSONContentType)
+
+	store, err := serv.service.GetStore(c.UserContext(), c.Params("id"))
+
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(store)
+}
+
+func (serv *CatalogHTTPServer) UpdateStore(c *fiber.Ctx) error {
+	c.Append("content-type", JSONContentType)
+
+	storeToUpdate := new(UpdateStoreInputDTO)
+
+	err := parseBody(c, storeToUpdate)
+
+	if err != nil {
+		return err
+	}
+
+	store, err := serv.service.UpdateStore(c.UserContext(), c.Params("id"), domain.UpdateStoreDTO{Name: storeToUpdate.Name})
+
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(store)
+}
+
+func (serv *CatalogHTTPServer) GetStoreMowers(c *fiber.Ctx) error {
+	c.Append("content-type", JSONContentType)
+
+	mowers, err := serv.service.GetStoreMowers(c.UserContext(), c.Params("id"))
+
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(mowers)
+}
@@ -0,0 +1,131 @@
+package restcontroller
+
+import (
+	"bytes"
+	"encoding/json"
+	lmTesting "jrobic/lawn-mower/catalog-service"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestStoreCtrl(t *testing.T) {
+	newServer := func() *CatalogHTTPServer {
+		repo := &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{
+			{ID: "1", Name: "M-90"},
+			{ID: "2", Name: "M-150"},
+		}}
+		stores := &lmTesting.StubStoreRepository{Stores: []*domain.Store{{ID: "1", Name: "Lyon"}}}
+		inventory := &lmTesting.StubInventoryRepository{Units: []*domain.InventoryUnit{
+			{ID: "1", SerialNumber: "SN-1", ModelID: "2", StoreID: "1"},
+		}}
+		server, _ := NewCatalogHTTPServer(repo, WithStoreRepository(stores), WithInventoryRepository(inventory))
+
+		return server
+	}
+
+	t.Run("CreateStoreCtrl return accepted on POST", func(t *testing.T) {
+		response, _ := newServer().App.Test(NewCreateStoreRequest(CreateStoreInputDTO{Name: "Paris"}), -1)
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusAccepted)
+		lmTesting.AssertContentType(t, response, JSONContentType)
+
+		got := lmTesting.GetStoreFromResponse(t, response.Body)
+
+		if got != (domain.Store{ID: "2", Name: "Paris"}) {
+			t.Errorf("got %v want store Paris (#2)", got)
+		}
+	})
+
+	t.Run("CreateStoreCtrl return 422 on a blank or long name", func(t *testing.T) {
+		for _, name := range []string{"", " ", strings.Repeat("L", domain.MaxNameLength+1)} {
+			response, _ := newServer().App.Test(NewCreateStoreRequest(CreateStoreInputDTO{Name: name}), -1)
+
+			got := AssertProblem(t, response, http.StatusUnprocessableEntity, "urn:lawn-mower:catalog:validation-failed")
+
+			if len(got.Errors) != 1 || got.Errors[0].Field != "name" || !strings.HasPrefix(got.Detail, "[Catalog] Invalid store: ") {
+				t.Errorf("name %q: got %+v want an invalid store name", name, got)
+			}
+		}
+	})
+
+	t.Run("GetStoreCtrl return store", func(t *testing.T) {
+		response, _ := newServer().App.Test(NewGetStoreRequest("1"), -1)
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusOK)
+
+		if got := lmTesting.GetStoreFromResponse(t, response.Body); got.Name != "Lyon" {
+			t.Errorf("got %v want store Lyon", got)
+		}
+	})
+
+	t.Run("GetStoreCtrl return 404 on missing store", func(t *testing.T) {
+		response, _ := newServer().App.Test(NewGetStoreRequest("6"), -1)
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusNotFound)
+	})
+
+	t.Run("UpdateStoreCtrl return updated store on PATCH", func(t *testing.T) {
+		response, _ := newServer().App.Test(NewUpdateStoreRequest("1", UpdateStoreInputDTO{Name: "Lyon Part-Dieu"}), -1)
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusOK)
+
+		if got := lmTesting.GetStoreFromResponse(t, response.Body); got.Name != "Lyon Part-Dieu" {
+			t.Errorf("got %v want store Lyon Part-Dieu", got)
+		}
+	})
+
+	t.Run("UpdateStoreCtrl return 422 on a blank name", func(t *testing.T) {
+		response, _ := newServer().App.Test(NewUpdateStoreRequest("1", UpdateStoreInputDTO{Name: " "}), -1)
+
+		got := AssertProblem(t, response, http.StatusUnprocessableEntity, "urn:lawn-mower:catalog:validation-failed")
+
+		if len(got.Errors) != 1 || got.Errors[0].Field != "name" {
+			t.Errorf("got %+v want an error on name", got)
+		}
+	})
+
+	t.Run("GetStoreMowersCtrl return stocked models", func(t *testing.T) {
+		response, _ := newServer().App.Test(NewGetStoreMowersRequest("1"), -1)
+
+		lmTesting.AssertStatus(t, response.StatusCode, http.StatusOK)
+		lmTesting.AssertCatalogEquals(t, lmTesting.GetCatalogFromResponse(t, response.Body), []*domain.Mower{{ID: "2", Name: "M-150"}})
+	})
+
+	t.Run("store routes return 503 without store repository", func(t *testing.T) {
+		server, _ := NewCatalogHTTPServer(&lmTesting.StubCatalogRepository{})
+
+		response, _ := server.App.Test(NewGetStoreRequest("1"), -1)
+
+		AssertProblem(t, response, http.StatusServiceUnavailable, "urn:lawn-mower:catalog:unavailable")
+	})
+}
+
+func NewCreateStoreRequest(body interface{}) *http.Request {
+	jsonBytes, _ := json.Marshal(body)
+
+	req, _ := http.NewRequest(http.MethodPost, "/stores", bytes.NewReader(jsonBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	return req
+}
+
+func NewUpdateStoreRequest(ID string, body interface{}) *http.Request {
+	jsonBytes, _ := json.Marshal(body)
+
+	req, _ := http.NewRequest(http.MethodPatch, "/stores/"+ID, bytes.NewReader(jsonBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	return req
+}
+
+func NewGetStoreRequest(ID string) *http.Request {
+	req, _ := http.NewRequest(http.MethodGet, "/stores/"+ID, nil)
+	return req
+}
+
+func NewGetStoreMowersRequest(ID string) *http.Request {
+	req, _ := http.NewRequest(http.MethodGet, "/stores/"+ID+"/mowers", nil)
+	return req
+}
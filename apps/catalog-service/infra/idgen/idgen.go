@@ -0,0 +1,116 @@
+package idgen
+
+import (
+	"fmt"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	FormatUUIDv4     = "uuidv4"
+	FormatUUIDv7     = "uuidv7"
+	FormatULID       = "ulid"
+	FormatSequential = "sequential"
+)
+
+// New returns the generator for one of the Format* names.
+func New(format string) (domain.IDGenerator, error) {
+	switch format {
+	case FormatUUIDv4:
+		return UUIDv4{}, nil
+	case FormatUUIDv7:
+		return UUIDv7{}, nil
+	case FormatULID:
+		return ULID{}, nil
+	case FormatSequential:
+		return NewSequential(0), nil
+	}
+
+	return nil, fmt.Errorf("unknown id format %q", format)
+}
+
+// IsUUID reports whether format produces ids a Postgres uuid column accepts.
+func IsUUID(format string) bool {
+	return format == FormatUUIDv4 || format == FormatUUIDv7
+}
+
+type UUIDv4 struct{}
+
+func (UUIDv4) NewID() string {
+	return uuid.New().String()
+}
+
+func (UUIDv4) Valid(id string) bool {
+	return validUUID(id, 4)
+}
+
+// UUIDv7 ids start with a millisecond timestamp, so they sort by creation
+// time, which keeps database indexes compact.
+type UUIDv7 struct{}
+
+func (UUIDv7) NewID() string {
+	return uuid.Must(uuid.NewV7()).String()
+}
+
+func (UUIDv7) Valid(id string) bool {
+	return validUUID(id, 7)
+}
+
+type ULID struct{}
+
+func (ULID) NewID() string {
+	return ulid.Make().String()
+}
+
+func (ULID) Valid(id string) bool {
+	_, err := ulid.ParseStrict(id)
+	return err == nil
+}
+
+// Sequential counts 1, 2, 3... It is deterministic, which makes it handy in
+// tests, and never reuses a value even after deletions.
+type Sequential struct {
+	last uint64
+}
+
+// NewSequential returns a generator whose first id is after + 1.
+func NewSequential(after uint64) *Sequential {
+	return &Sequential{last: after}
+}
+
+func (s *Sequential) NewID() string {
+	return strconv.FormatUint(atomic.AddUint64(&s.last, 1), 10)
+}
+
+// Observe makes sure id, handed out by an earlier run, is never handed out
+// again.
+func (s *Sequential) Observe(id string) {
+	n, err := strconv.ParseUint(id, 10, 64)
+
+	if err != nil {
+		return
+	}
+
+	for {
+		last := atomic.LoadUint64(&s.last)
+
+		if n <= last || atomic.CompareAndSwapUint64(&s.last, last, n) {
+			return
+		}
+	}
+}
+
+func (s *Sequential) Valid(id string) bool {
+	n, err := strconv.ParseUint(id, 10, 64)
+	return err == nil && n > 0 && strconv.FormatUint(n, 10) == id
+}
+
+func validUUID(id string, version uuid.Version) bool {
+	parsed, err := uuid.Parse(id)
+
+	return err == nil && len(id) == 36 && parsed.Version() == version && parsed.Variant() == uuid.RFC4122
+}
@@ -0,0 +1,82 @@
+package idgen
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestGenerators(t *testing.T) {
+	for _, format := range []string{FormatUUIDv4, FormatUUIDv7, FormatULID, FormatSequential} {
+		t.Run(format, func(t *testing.T) {
+			generator, err := New(format)
+
+			if err != nil {
+				t.Fatalf("didn't expect an error but got one, %v", err)
+			}
+
+			seen := map[string]bool{}
+
+			for i := 0; i < 1000; i++ {
+				id := generator.NewID()
+
+				if seen[id] {
+					t.Fatalf("id %q generated twice", id)
+				}
+
+				if !generator.Valid(id) {
+					t.Fatalf("generated id %q is not valid", id)
+				}
+
+				seen[id] = true
+			}
+
+			for _, malformed := range []string{"", "0", "01", "abc", "6", "not-a-uuid"} {
+				if format == FormatSequential && malformed == "6" {
+					continue
+				}
+
+				if generator.Valid(malformed) {
+					t.Errorf("expected %q to be malformed", malformed)
+				}
+			}
+		})
+	}
+}
+
+func TestUUIDVersions(t *testing.T) {
+	v4, v7 := UUIDv4{}.NewID(), UUIDv7{}.NewID()
+
+	if (UUIDv7{}).Valid(v4) || (UUIDv4{}).Valid(v7) {
+		t.Errorf("expected uuid versions to be told apart, got %q and %q", v4, v7)
+	}
+}
+
+func TestSequential(t *testing.T) {
+	generator := NewSequential(3)
+
+	if got := generator.NewID(); got != "4" {
+		t.Errorf("got %q want %q", got, "4")
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			generator.NewID()
+		}()
+	}
+
+	wg.Wait()
+
+	if got := generator.NewID(); got != "105" {
+		t.Errorf("got %q want %q", got, "105")
+	}
+}
+
+func TestNewUnknownFormat(t *testing.T) {
+	if _, err := New("snowflake"); err == nil {
+		t.Errorf("expected an error for an unknown format")
+	}
+}
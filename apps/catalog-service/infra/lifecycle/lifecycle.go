@@ -0,0 +1,188 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Hook is a component of the service, such as a server, a background worker
+// or a database pool. Either function may be nil.
+type Hook struct {
+	Name string
+	// OnStart starts the component. It must return once started, handing
+	// long running work, such as serving, to Lifecycle.Go.
+	OnStart func(ctx context.Context) error
+	// OnStop stops the component, giving up once ctx is done.
+	OnStop func(ctx context.Context) error
+}
+
+// Lifecycle starts hooks in the order they are appended and stops them in
+// reverse order, so that servers stop taking requests before the resources
+// they use are closed.
+type Lifecycle struct {
+	stopTimeout time.Duration
+	hooks       []Hook
+	failures    chan error
+	background  sync.WaitGroup
+	lock        sync.Mutex
+	stopping    bool
+}
+
+// New returns a lifecycle giving its hooks stopTimeout to stop, all together.
+func New(stopTimeout time.Duration) *Lifecycle {
+	return &Lifecycle{stopTimeout: stopTimeout, failures: make(chan error, 1)}
+}
+
+func (l *Lifecycle) Append(hook Hook) {
+	l.hooks = append(l.hooks, hook)
+}
+
+// Go runs fn in the background. The service stops when fn returns before it
+// has been asked to, such as a server failing to serve.
+func (l *Lifecycle) Go(name string, fn func() error) {
+	l.background.Add(1)
+
+	go func() {
+		defer l.background.Done()
+
+		err := fn()
+
+		l.lock.Lock()
+		stopping := l.stopping
+		l.lock.Unlock()
+
+		if stopping {
+			return
+		}
+
+		if err == nil {
+			err = fmt.Errorf("%s stopped", name)
+		}
+
+		select {
+		case l.failures <- fmt.Errorf("%s: %w", name, err):
+		default:
+		}
+	}()
+}
+
+// Worker returns the hook of a background worker: run is started with a
+// context cancelled when the hook stops, which then waits for run to return.
+func (l *Lifecycle) Worker(name string, run func(ctx context.Context)) Hook {
+	var (
+		cancel context.CancelFunc
+		done   chan struct{}
+	)
+
+	return Hook{
+		Name: name,
+		OnStart: func(context.Context) error {
+			var ctx context.Context
+
+			ctx, cancel = context.WithCancel(context.Background())
+			done = make(chan struct{})
+
+			go func() {
+				defer close(done)
+				run(ctx)
+			}()
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancel()
+
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	}
+}
+
+// Run starts every hook, then waits for ctx to be done or for a background
+// function to fail before stopping the started hooks. A hook failing to start
+// stops the ones started before it. Run returns the error that stopped the
+// service, if any, or else the first error met while stopping.
+func (l *Lifecycle) Run(ctx context.Context) error {
+	started := 0
+
+	var err error
+
+	for _, hook := range l.hooks {
+		if hook.OnStart != nil {
+			if err = hook.OnStart(ctx); err != nil {
+				err = fmt.Errorf("starting %s: %w", hook.Name, err)
+				break
+			}
+		}
+
+		started++
+	}
+
+	if err == nil {
+		select {
+		case <-ctx.Done():
+			log.Println("shutting down")
+		case err = <-l.failures:
+			log.Printf("shutting down: %v", err)
+		}
+	}
+
+	if stopErr := l.stop(started); err == nil {
+		err = stopErr
+	}
+
+	return err
+}
+
+// stop stops the first started hooks in reverse order, then waits for the
+// background functions to return, all within stopTimeout.
+func (l *Lifecycle) stop(started int) error {
+	l.lock.Lock()
+	l.stopping = true
+	l.lock.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), l.stopTimeout)
+	defer cancel()
+
+	var err error
+
+	for i := started - 1; i >= 0; i-- {
+		hook := l.hooks[i]
+
+		if hook.OnStop == nil {
+			continue
+		}
+
+		if stopErr := hook.OnStop(ctx); stopErr != nil {
+			log.Printf("stopping %s: %v", hook.Name, stopErr)
+
+			if err == nil {
+				err = fmt.Errorf("stopping %s: %w", hook.Name, stopErr)
+			}
+		}
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		l.background.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		if err == nil {
+			err = fmt.Errorf("waiting for background functions: %w", ctx.Err())
+		}
+	}
+
+	return err
+}
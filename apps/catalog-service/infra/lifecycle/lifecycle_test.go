@@ -0,0 +1,145 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	lmTesting "jrobic/lawn-mower/catalog-service"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder records the hooks starting and stopping.
+type recorder struct {
+	lock   sync.Mutex
+	events []string
+}
+
+func (r *recorder) hook(name string, startErr error) Hook {
+	return Hook{
+		Name: name,
+		OnStart: func(context.Context) error {
+			r.record("start " + name)
+			return startErr
+		},
+		OnStop: func(context.Context) error {
+			r.record("stop " + name)
+			return nil
+		},
+	}
+}
+
+func (r *recorder) record(event string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) assertEvents(t *testing.T, want ...string) {
+	t.Helper()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !reflect.DeepEqual(r.events, want) {
+		t.Errorf("got %v want %v", r.events, want)
+	}
+}
+
+func TestLifecycle(t *testing.T) {
+	t.Run("stop hooks in reverse order", func(t *testing.T) {
+		r := &recorder{}
+		lc := New(time.Second)
+
+		lc.Append(r.hook("database", nil))
+		lc.Append(r.hook("server", nil))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		lmTesting.AssertNoError(t, lc.Run(ctx))
+
+		r.assertEvents(t, "start database", "start server", "stop server", "stop database")
+	})
+
+	t.Run("stop the started hooks when one fails to start", func(t *testing.T) {
+		r := &recorder{}
+		lc := New(time.Second)
+		failure := errors.New("address already in use")
+
+		lc.Append(r.hook("database", nil))
+		lc.Append(r.hook("server", failure))
+		lc.Append(r.hook("worker", nil))
+
+		if err := lc.Run(context.Background()); !errors.Is(err, failure) {
+			t.Errorf("got %v want %v", err, failure)
+		}
+
+		r.assertEvents(t, "start database", "start server", "stop database")
+	})
+
+	t.Run("stop when a background function fails", func(t *testing.T) {
+		r := &recorder{}
+		lc := New(time.Second)
+		failure := errors.New("connection reset")
+
+		lc.Append(r.hook("database", nil))
+		lc.Append(Hook{Name: "server", OnStart: func(context.Context) error {
+			lc.Go("server", func() error {
+				return failure
+			})
+
+			return nil
+		}})
+
+		if err := lc.Run(context.Background()); !errors.Is(err, failure) {
+			t.Errorf("got %v want %v", err, failure)
+		}
+
+		r.assertEvents(t, "start database", "stop database")
+	})
+
+	t.Run("wait for workers to return", func(t *testing.T) {
+		lc := New(time.Second)
+		flushed := false
+
+		lc.Append(lc.Worker("publisher", func(ctx context.Context) {
+			<-ctx.Done()
+			time.Sleep(10 * time.Millisecond)
+			flushed = true
+		}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		lmTesting.AssertNoError(t, lc.Run(ctx))
+
+		if !flushed {
+			t.Error("expected the worker to return before Run")
+		}
+	})
+
+	t.Run("give up stopping after the timeout", func(t *testing.T) {
+		lc := New(10 * time.Millisecond)
+		release := make(chan struct{})
+		defer close(release)
+
+		lc.Append(Hook{Name: "server", OnStop: func(ctx context.Context) error {
+			select {
+			case <-release:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if err := lc.Run(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("got %v want %v", err, context.DeadlineExceeded)
+		}
+	})
+}
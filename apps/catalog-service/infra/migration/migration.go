@@ -0,0 +1,143 @@
+package migration
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+//go:embed sql/*.sql
+var embedded embed.FS
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+
+	hasUp, hasDown bool
+}
+
+// Embedded returns the catalog migrations shipped with the binary.
+func Embedded() ([]Migration, error) {
+	sub, err := fs.Sub(embedded, "sql")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return Load(sub)
+}
+
+// Load reads `<version>_<name>.(up|down).sql` files from the root of fsys and
+// returns them ordered by version. Every version needs both an up and a down
+// file.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[uint64]*Migration{}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		parts := fileNamePattern.FindStringSubmatch(entry.Name())
+
+		if parts == nil {
+			return nil, fmt.Errorf("migration: unexpected file name %q", entry.Name())
+		}
+
+		version, err := strconv.ParseUint(parts[1], 10, 64)
+
+		if err != nil {
+			return nil, fmt.Errorf("migration: bad version in %q: %w", entry.Name(), err)
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+
+		if !ok {
+			m = &Migration{Version: version, Name: parts[2]}
+			byVersion[version] = m
+		}
+
+		if m.Name != parts[2] {
+			return nil, fmt.Errorf("migration: version %d used by %q and %q", version, m.Name, parts[2])
+		}
+
+		if parts[3] == "up" {
+			m.Up, m.hasUp = string(content), true
+		} else {
+			m.Down, m.hasDown = string(content), true
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+
+	for _, m := range byVersion {
+		if !m.hasUp || !m.hasDown {
+			return nil, fmt.Errorf("migration: version %d (%s) needs both up and down files", m.Version, m.Name)
+		}
+
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Create writes an empty up/down pair for name in dir, numbered after the
+// highest version already present, and returns the created file paths.
+func Create(dir, name string) ([]string, error) {
+	if !regexp.MustCompile(`^[a-z0-9_]+$`).MatchString(name) {
+		return nil, fmt.Errorf("migration: name %q must be snake_case", name)
+	}
+
+	migrations, err := Load(os.DirFS(dir))
+
+	if err != nil {
+		return nil, err
+	}
+
+	var version uint64 = 1
+
+	if len(migrations) > 0 {
+		version = migrations[len(migrations)-1].Version + 1
+	}
+
+	paths := []string{}
+
+	for _, direction := range []string{"up", "down"} {
+		path := filepath.Join(dir, fmt.Sprintf("%04d_%s.%s.sql", version, name, direction))
+
+		content := fmt.Sprintf("-- %s migration %04d_%s\n", direction, version, name)
+
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return nil, err
+		}
+
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
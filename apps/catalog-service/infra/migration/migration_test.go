@@ -0,0 +1,236 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	lmTesting "jrobic/lawn-mower/catalog-service"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+func TestLoad(t *testing.T) {
+	t.Run("orders migrations by version", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"0010_add_index.up.sql":      {Data: []byte("CREATE INDEX")},
+			"0010_add_index.down.sql":    {Data: []byte("DROP INDEX")},
+			"0002_create_table.up.sql":   {Data: []byte("CREATE TABLE")},
+			"0002_create_table.down.sql": {Data: []byte("DROP TABLE")},
+		}
+
+		got, err := Load(fsys)
+		lmTesting.AssertNoError(t, err)
+
+		if len(got) != 2 || got[0].Version != 2 || got[1].Version != 10 {
+			t.Fatalf("got %+v", got)
+		}
+
+		if got[0].Name != "create_table" || got[0].Up != "CREATE TABLE" || got[0].Down != "DROP TABLE" {
+			t.Errorf("got %+v", got[0])
+		}
+	})
+
+	t.Run("rejects a migration without down file", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"0001_create_table.up.sql": {Data: []byte("CREATE TABLE")},
+		}
+
+		_, err := Load(fsys)
+
+		lmTesting.AssertError(t, err, "migration: version 1 (create_table) needs both up and down files")
+	})
+
+	t.Run("rejects two names for one version", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"0001_a.up.sql":   {Data: []byte("")},
+			"0001_a.down.sql": {Data: []byte("")},
+			"0001_b.up.sql":   {Data: []byte("")},
+		}
+
+		_, err := Load(fsys)
+
+		lmTesting.AssertError(t, err, `migration: version 1 used by "a" and "b"`)
+	})
+
+	t.Run("rejects unexpected files", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"README.md": {Data: []byte("")},
+		}
+
+		_, err := Load(fsys)
+
+		lmTesting.AssertError(t, err, `migration: unexpected file name "README.md"`)
+	})
+}
+
+func TestEmbedded(t *testing.T) {
+	migrations, err := Embedded()
+	lmTesting.AssertNoError(t, err)
+
+	for i, m := range migrations {
+		if m.Version != uint64(i+1) {
+			t.Errorf("expected contiguous versions, got %d at position %d", m.Version, i)
+		}
+	}
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{"0001_init.up.sql", "0001_init.down.sql"} {
+		lmTesting.AssertNoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o644))
+	}
+
+	paths, err := Create(dir, "add_stores")
+	lmTesting.AssertNoError(t, err)
+
+	want := []string{
+		filepath.Join(dir, "0002_add_stores.up.sql"),
+		filepath.Join(dir, "0002_add_stores.down.sql"),
+	}
+
+	if len(paths) != 2 || paths[0] != want[0] || paths[1] != want[1] {
+		t.Errorf("got %v want %v", paths, want)
+	}
+
+	migrations, err := Load(os.DirFS(dir))
+	lmTesting.AssertNoError(t, err)
+
+	if len(migrations) != 2 {
+		t.Errorf("expected created migration to load, got %+v", migrations)
+	}
+
+	_, err = Create(dir, "Add Stores")
+
+	lmTesting.AssertError(t, err, `migration: name "Add Stores" must be snake_case`)
+}
+
+func TestMigrator(t *testing.T) {
+	dsn := os.Getenv("CATALOG_TEST_DATABASE_URL")
+
+	if dsn == "" {
+		t.Skip("CATALOG_TEST_DATABASE_URL is not set")
+	}
+
+	db := openIsolatedSchema(t, dsn, "migrator_test")
+	ctx := context.Background()
+
+	migrator := NewMigrator(db, []Migration{
+		{Version: 1, Name: "create", Up: `CREATE TABLE widgets (id int)`, Down: `DROP TABLE widgets`},
+		{Version: 2, Name: "broken", Up: `ALTER TABLE missing ADD COLUMN x int`, Down: ``},
+	})
+
+	statuses, err := migrator.Status(ctx)
+	lmTesting.AssertNoError(t, err)
+
+	if len(statuses) != 2 || statuses[0].Applied {
+		t.Errorf("expected two pending migrations before the first run, got %+v", statuses)
+	}
+
+	applied, err := migrator.Up(ctx)
+
+	if err == nil || len(applied) != 1 {
+		t.Fatalf("expected first migration applied then a failure, got %v %v", applied, err)
+	}
+
+	_, err = migrator.Up(ctx)
+
+	if !errors.Is(err, ErrDirty) {
+		t.Fatalf("expected dirty error, got %v", err)
+	}
+
+	lmTesting.AssertNoError(t, migrator.Force(ctx, 1))
+
+	reverted, err := migrator.Down(ctx, 1)
+	lmTesting.AssertNoError(t, err)
+
+	if len(reverted) != 1 || reverted[0].Version != 1 {
+		t.Errorf("got %v", reverted)
+	}
+
+	// as if another replica were migrating
+	conn, err := db.Conn(ctx)
+	lmTesting.AssertNoError(t, err)
+
+	defer conn.Close()
+
+	_, err = conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockKey)
+	lmTesting.AssertNoError(t, err)
+
+	timeout, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	statuses, err = migrator.Status(timeout)
+	lmTesting.AssertNoError(t, err)
+
+	for _, s := range statuses {
+		if s.Applied {
+			t.Errorf("expected %d to be pending", s.Version)
+		}
+	}
+}
+
+func TestMowerNameKey(t *testing.T) {
+	dsn := os.Getenv("CATALOG_TEST_DATABASE_URL")
+
+	if dsn == "" {
+		t.Skip("CATALOG_TEST_DATABASE_URL is not set")
+	}
+
+	db := openIsolatedSchema(t, dsn, "name_key_test")
+	ctx := context.Background()
+
+	migrations, err := Embedded()
+	lmTesting.AssertNoError(t, err)
+
+	_, err = NewMigrator(db, migrations[:7]).Up(ctx)
+	lmTesting.AssertNoError(t, err)
+
+	_, err = db.ExecContext(ctx, `INSERT INTO mowers (name) VALUES ('M-90'), ('m-90'), ('M-91'), ('M-92'), ('m-92')`)
+	lmTesting.AssertNoError(t, err)
+
+	_, err = NewMigrator(db, migrations).Up(ctx)
+
+	if err == nil || !strings.Contains(err.Error(), "rename the mowers sharing 'm-90' (") || !strings.Contains(err.Error(), "'m-92' (") {
+		t.Errorf("got %v want both duplicated names listed", err)
+	}
+}
+
+// openIsolatedSchema recreates schema and returns a pool whose search_path
+// points at it, so the test does not touch the catalog tables.
+func openIsolatedSchema(t testing.TB, dsn, schema string) *sql.DB {
+	t.Helper()
+
+	admin, err := sql.Open("postgres", dsn)
+	lmTesting.AssertNoError(t, err)
+
+	defer admin.Close()
+
+	_, err = admin.Exec(`DROP SCHEMA IF EXISTS ` + schema + ` CASCADE; CREATE SCHEMA ` + schema)
+	lmTesting.AssertNoError(t, err)
+
+	if strings.Contains(dsn, "://") {
+		separator := "?"
+
+		if strings.Contains(dsn, "?") {
+			separator = "&"
+		}
+
+		dsn += separator + "search_path=" + schema
+	} else {
+		dsn += " search_path=" + schema
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	lmTesting.AssertNoError(t, err)
+
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
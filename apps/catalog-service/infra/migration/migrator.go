@@ -0,0 +1,274 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// lockKey identifies the catalog migrations in pg_advisory_lock so that
+// concurrent replicas wait for each other instead of migrating twice.
+const lockKey int64 = 0x6c6d2d636174616c
+
+const versionTable = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+	version    bigint PRIMARY KEY,
+	name       text NOT NULL,
+	dirty      boolean NOT NULL,
+	applied_at timestamptz NOT NULL DEFAULT now()
+)`
+
+var ErrDirty = errors.New("migration: database is dirty, fix it manually then run force")
+
+type Status struct {
+	Migration
+	Applied   bool
+	Dirty     bool
+	AppliedAt *time.Time
+}
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+func NewMigrator(db *sql.DB, migrations []Migration) *Migrator {
+	return &Migrator{db: db, migrations: migrations}
+}
+
+// Up applies every pending migration in order and returns the applied ones.
+func (m *Migrator) Up(ctx context.Context) (applied []Migration, err error) {
+	err = m.withLock(ctx, func(conn *sql.Conn) error {
+		current, err := m.applied(ctx, conn)
+
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := current[migration.Version]; ok {
+				continue
+			}
+
+			if err := m.run(ctx, conn, migration, migration.Up, true); err != nil {
+				return err
+			}
+
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down reverts the n most recently applied migrations and returns them.
+func (m *Migrator) Down(ctx context.Context, n int) (reverted []Migration, err error) {
+	err = m.withLock(ctx, func(conn *sql.Conn) error {
+		current, err := m.applied(ctx, conn)
+
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < n; i-- {
+			migration := m.migrations[i]
+
+			if _, ok := current[migration.Version]; !ok {
+				continue
+			}
+
+			if err := m.run(ctx, conn, migration, migration.Down, false); err != nil {
+				return err
+			}
+
+			reverted = append(reverted, migration)
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Force records version as cleanly applied, and every later version as not
+// applied, without running any SQL. It is the way out of a dirty state once
+// the schema has been repaired by hand; version 0 means nothing is applied.
+func (m *Migrator) Force(ctx context.Context, version uint64) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		if _, err := conn.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version > $1`, version); err != nil {
+			return err
+		}
+
+		if version == 0 {
+			return nil
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version == version {
+				_, err := conn.ExecContext(ctx,
+					`INSERT INTO schema_migrations (version, name, dirty) VALUES ($1, $2, false)
+					ON CONFLICT (version) DO UPDATE SET dirty = false`,
+					migration.Version, migration.Name,
+				)
+				return err
+			}
+		}
+
+		return fmt.Errorf("migration: unknown version %d", version)
+	})
+}
+
+// Status lists every known migration along with its state in the database.
+// It reads schema_migrations without taking the migration lock, so that it
+// answers while another replica migrates; the version being applied then
+// shows as dirty until it is done.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var exists bool
+
+	// nothing was ever migrated until Up creates the table
+	if err := m.db.QueryRowContext(ctx, `SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists); err != nil {
+		return nil, err
+	}
+
+	states := map[uint64]Status{}
+
+	if exists {
+		rows, err := m.db.QueryContext(ctx, `SELECT version, dirty, applied_at FROM schema_migrations`)
+
+		if err != nil {
+			return nil, err
+		}
+
+		defer rows.Close()
+
+		for rows.Next() {
+			var s Status
+			var appliedAt time.Time
+
+			if err := rows.Scan(&s.Version, &s.Dirty, &appliedAt); err != nil {
+				return nil, err
+			}
+
+			s.Applied, s.AppliedAt = true, &appliedAt
+			states[s.Version] = s
+		}
+
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+
+	for _, migration := range m.migrations {
+		s := states[migration.Version]
+		s.Migration = migration
+		statuses = append(statuses, s)
+	}
+
+	return statuses, nil
+}
+
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) (err error) {
+	conn, err := m.db.Conn(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockKey); err != nil {
+		return fmt.Errorf("migration: could not acquire lock: %w", err)
+	}
+
+	defer func() {
+		_, unlockErr := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, lockKey)
+
+		if err == nil {
+			err = unlockErr
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, versionTable); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+// applied returns the applied versions, failing with ErrDirty when a previous
+// run stopped halfway.
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[uint64]struct{}, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, dirty FROM schema_migrations`)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	versions := map[uint64]struct{}{}
+
+	for rows.Next() {
+		var version uint64
+		var dirty bool
+
+		if err := rows.Scan(&version, &dirty); err != nil {
+			return nil, err
+		}
+
+		if dirty {
+			return nil, fmt.Errorf("%w (version %d)", ErrDirty, version)
+		}
+
+		versions[version] = struct{}{}
+	}
+
+	return versions, rows.Err()
+}
+
+// run marks the migration dirty, executes script in a transaction and clears
+// the mark in that same transaction, so a failure leaves the version dirty.
+func (m *Migrator) run(ctx context.Context, conn *sql.Conn, migration Migration, script string, up bool) error {
+	_, err := conn.ExecContext(ctx,
+		`INSERT INTO schema_migrations (version, name, dirty) VALUES ($1, $2, true)
+		ON CONFLICT (version) DO UPDATE SET dirty = true`,
+		migration.Version, migration.Name,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("migration: %04d_%s failed: %w", migration.Version, migration.Name, err)
+	}
+
+	if up {
+		_, err = tx.ExecContext(ctx,
+			`UPDATE schema_migrations SET dirty = false, applied_at = now() WHERE version = $1`,
+			migration.Version,
+		)
+	} else {
+		_, err = tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = $1`, migration.Version)
+	}
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
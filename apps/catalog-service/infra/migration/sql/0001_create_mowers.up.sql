@@ -0,0 +1,7 @@
+CREATE TABLE mowers (
+	id         uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+	created_at timestamptz NOT NULL DEFAULT now(),
+	updated_at timestamptz NOT NULL DEFAULT now(),
+	deleted_at timestamptz,
+	name       text NOT NULL
+);
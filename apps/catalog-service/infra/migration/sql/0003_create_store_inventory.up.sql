@@ -0,0 +1,12 @@
+CREATE TABLE store_inventory (
+	id         uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+	created_at timestamptz NOT NULL DEFAULT now(),
+	updated_at timestamptz NOT NULL DEFAULT now(),
+	deleted_at timestamptz,
+	ns         text NOT NULL UNIQUE,
+	model      uuid NOT NULL REFERENCES mowers (id),
+	store_id   uuid NOT NULL REFERENCES stores (id)
+);
+
+CREATE INDEX store_inventory_model_idx ON store_inventory (model);
+CREATE INDEX store_inventory_store_id_idx ON store_inventory (store_id);
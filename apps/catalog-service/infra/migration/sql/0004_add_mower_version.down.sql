@@ -0,0 +1 @@
+ALTER TABLE mowers DROP COLUMN version;
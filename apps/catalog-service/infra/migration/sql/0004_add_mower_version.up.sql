@@ -0,0 +1 @@
+ALTER TABLE mowers ADD COLUMN version bigint NOT NULL DEFAULT 1;
@@ -0,0 +1,10 @@
+ALTER TABLE mowers
+	DROP COLUMN power_source,
+	DROP COLUMN cutting_width_cm,
+	DROP COLUMN cutting_height_min_mm,
+	DROP COLUMN cutting_height_max_mm,
+	DROP COLUMN grass_bag_liters,
+	DROP COLUMN weight_kg,
+	DROP COLUMN self_propelled,
+	DROP COLUMN recommended_area_m2,
+	DROP COLUMN description;
@@ -0,0 +1,10 @@
+ALTER TABLE mowers
+	ADD COLUMN power_source          text NOT NULL DEFAULT '',
+	ADD COLUMN cutting_width_cm      integer NOT NULL DEFAULT 0,
+	ADD COLUMN cutting_height_min_mm integer NOT NULL DEFAULT 0,
+	ADD COLUMN cutting_height_max_mm integer NOT NULL DEFAULT 0,
+	ADD COLUMN grass_bag_liters      integer NOT NULL DEFAULT 0,
+	ADD COLUMN weight_kg             double precision NOT NULL DEFAULT 0,
+	ADD COLUMN self_propelled        boolean NOT NULL DEFAULT false,
+	ADD COLUMN recommended_area_m2   integer NOT NULL DEFAULT 0,
+	ADD COLUMN description           text NOT NULL DEFAULT '';
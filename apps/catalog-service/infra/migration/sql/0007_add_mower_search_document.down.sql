@@ -0,0 +1,3 @@
+DROP INDEX mowers_search_document_idx;
+
+ALTER TABLE mowers DROP COLUMN search_document;
@@ -0,0 +1,8 @@
+ALTER TABLE mowers
+	ADD COLUMN search_document tsvector GENERATED ALWAYS AS (
+		setweight(to_tsvector('simple', name || ' ' || regexp_replace(name, '[^[:alnum:]]+', '', 'g')), 'A') ||
+		setweight(to_tsvector('simple', power_source), 'B') ||
+		setweight(to_tsvector('simple', description), 'C')
+	) STORED;
+
+CREATE INDEX mowers_search_document_idx ON mowers USING GIN (search_document);
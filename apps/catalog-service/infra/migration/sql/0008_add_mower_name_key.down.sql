@@ -0,0 +1 @@
+DROP INDEX mowers_name_key;
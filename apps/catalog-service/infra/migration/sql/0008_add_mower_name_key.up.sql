@@ -0,0 +1,23 @@
+-- names differing only by case, which the catalog accepted until now, would
+-- fail the index below with the first conflicting pair only: list them all
+DO $$
+DECLARE
+	duplicates text;
+BEGIN
+	SELECT string_agg(format('%L (%s)', key, ids), ', ' ORDER BY key)
+	INTO duplicates
+	FROM (
+		SELECT lower(name) AS key, string_agg(id::text, ', ' ORDER BY created_at) AS ids
+		FROM mowers
+		GROUP BY lower(name)
+		HAVING count(*) > 1
+	) AS taken;
+
+	IF duplicates IS NOT NULL THEN
+		RAISE EXCEPTION 'mower names must be unique regardless of case, rename the mowers sharing %', duplicates
+			USING HINT = 'once renamed, run force 7 then up';
+	END IF;
+END
+$$;
+
+CREATE UNIQUE INDEX mowers_name_key ON mowers (lower(name));
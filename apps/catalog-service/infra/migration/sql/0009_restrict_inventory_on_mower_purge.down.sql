@@ -0,0 +1,3 @@
+ALTER TABLE store_inventory
+	DROP CONSTRAINT store_inventory_model_fkey,
+	ADD CONSTRAINT store_inventory_model_fkey FOREIGN KEY (model) REFERENCES mowers (id) ON DELETE CASCADE;
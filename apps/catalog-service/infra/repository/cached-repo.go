@@ -0,0 +1,298 @@
+package repository
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DefaultCacheSize        = 1000
+	DefaultCacheTTL         = time.Minute
+	DefaultCacheNegativeTTL = 5 * time.Second
+)
+
+type CacheOptions struct {
+	// Size is the number of ids kept, the least recently used going first
+	// past it. DefaultCacheSize when zero.
+	Size int
+	// TTL is how long a mower is served from the cache, DefaultCacheTTL
+	// when zero.
+	TTL time.Duration
+	// NegativeTTL is how long an unknown id is remembered as such. Unknown
+	// ids are not cached when zero.
+	NegativeTTL time.Duration
+}
+
+// CacheStats counts the lookups of a CachedRepo: Hits were answered from
+// the cache, Misses by the repository behind it.
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+// CachedRepo is a CatalogRepository caching the mowers another one finds by
+// id. Concurrent misses of the same id share a single lookup, and every
+// change made through CachedRepo drops the id from the cache. Changes made
+// around it, by another instance of the service for one, show after TTL.
+type CachedRepo struct {
+	next  domain.CatalogRepository
+	opts  CacheOptions
+	clock domain.Clock
+
+	lock    sync.Mutex
+	entries map[string]*list.Element
+	// recent lists the entries, the most recently used first.
+	recent  *list.List
+	flights map[string]*flight
+
+	hits   uint64
+	misses uint64
+}
+
+// cacheEntry is a mower of the cache, nil for an unknown id.
+type cacheEntry struct {
+	id      string
+	mower   *domain.Mower
+	expires time.Time
+}
+
+// flight is a lookup of the next repository the concurrent misses of an id
+// wait for.
+type flight struct {
+	done  chan struct{}
+	mower *domain.Mower
+	err   error
+	// stale is set when the mower changes during the lookup: the callers
+	// waiting for it get its result, but it is not cached.
+	stale bool
+}
+
+func NewCachedRepo(next domain.CatalogRepository, cacheOpts CacheOptions, opts ...Option) *CachedRepo {
+	o := newOptions(opts)
+
+	if cacheOpts.Size <= 0 {
+		cacheOpts.Size = DefaultCacheSize
+	}
+
+	if cacheOpts.TTL <= 0 {
+		cacheOpts.TTL = DefaultCacheTTL
+	}
+
+	return &CachedRepo{
+		next:    next,
+		opts:    cacheOpts,
+		clock:   o.clock,
+		entries: map[string]*list.Element{},
+		recent:  list.New(),
+		flights: map[string]*flight{},
+	}
+}
+
+// Stats returns the lookups counted since the repository was made.
+func (r *CachedRepo) Stats() CacheStats {
+	return CacheStats{Hits: atomic.LoadUint64(&r.hits), Misses: atomic.LoadUint64(&r.misses)}
+}
+
+func (r *CachedRepo) Find(ctx context.Context, id string) (*domain.Mower, error) {
+	for {
+		mower, shared, err := r.find(ctx, id)
+
+		// The caller the lookup was made for gave up on it: look again
+		// rather than fail for it.
+		if shared && ctx.Err() == nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+			continue
+		}
+
+		return mower, err
+	}
+}
+
+// find answers from the cache, or else from a lookup of the next repository
+// it makes or, when shared is set, waits for.
+func (r *CachedRepo) find(ctx context.Context, id string) (mower *domain.Mower, shared bool, err error) {
+	r.lock.Lock()
+
+	if entry, ok := r.lookup(id); ok {
+		r.lock.Unlock()
+		atomic.AddUint64(&r.hits, 1)
+
+		return copyOf(entry.mower), false, nil
+	}
+
+	atomic.AddUint64(&r.misses, 1)
+
+	f, shared := r.flights[id]
+
+	if !shared {
+		f = &flight{done: make(chan struct{})}
+		r.flights[id] = f
+	}
+
+	r.lock.Unlock()
+
+	if shared {
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+	} else {
+		r.fly(ctx, id, f)
+	}
+
+	if f.err != nil {
+		return nil, shared, f.err
+	}
+
+	return copyOf(f.mower), shared, nil
+}
+
+// fly looks id up in the next repository for f, then caches what it found
+// unless the mower changed in the meantime.
+func (r *CachedRepo) fly(ctx context.Context, id string, f *flight) {
+	defer close(f.done)
+
+	f.mower, f.err = r.next.Find(ctx, id)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.flights[id] == f {
+		delete(r.flights, id)
+	}
+
+	if f.err == nil && !f.stale {
+		r.store(id, f.mower)
+	}
+}
+
+// lookup returns the live entry of id. The caller holds the lock.
+func (r *CachedRepo) lookup(id string) (*cacheEntry, bool) {
+	element, ok := r.entries[id]
+
+	if !ok {
+		return nil, false
+	}
+
+	entry := element.Value.(*cacheEntry)
+
+	if !r.clock.Now().Before(entry.expires) {
+		r.recent.Remove(element)
+		delete(r.entries, id)
+
+		return nil, false
+	}
+
+	r.recent.MoveToFront(element)
+
+	return entry, true
+}
+
+// store caches mower, or that id is unknown when it is nil, evicting the
+// least recently used entry past Size. The caller holds the lock.
+func (r *CachedRepo) store(id string, mower *domain.Mower) {
+	ttl := r.opts.TTL
+
+	if mower == nil {
+		ttl = r.opts.NegativeTTL
+	}
+
+	if ttl <= 0 {
+		return
+	}
+
+	entry := &cacheEntry{id: id, mower: mower, expires: r.clock.Now().Add(ttl)}
+
+	if element, ok := r.entries[id]; ok {
+		element.Value = entry
+		r.recent.MoveToFront(element)
+
+		return
+	}
+
+	r.entries[id] = r.recent.PushFront(entry)
+
+	if r.recent.Len() > r.opts.Size {
+		oldest := r.recent.Back()
+		r.recent.Remove(oldest)
+		delete(r.entries, oldest.Value.(*cacheEntry).id)
+	}
+}
+
+// invalidate drops id from the cache, and keeps a lookup of id in flight
+// from caching what it finds.
+func (r *CachedRepo) invalidate(id string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if element, ok := r.entries[id]; ok {
+		r.recent.Remove(element)
+		delete(r.entries, id)
+	}
+
+	if f, ok := r.flights[id]; ok {
+		f.stale = true
+		delete(r.flights, id)
+	}
+}
+
+// Add drops the id of the new mower, which may have been looked up before.
+func (r *CachedRepo) Add(ctx context.Context, input domain.CreateMowerDTO) (*domain.Mower, error) {
+	mower, err := r.next.Add(ctx, input)
+
+	if mower != nil {
+		r.invalidate(mower.ID)
+	}
+
+	return mower, err
+}
+
+func (r *CachedRepo) Patch(ctx context.Context, id string, input domain.UpdateMowerDTO, expectedVersion int64) (*domain.Mower, error) {
+	defer r.invalidate(id)
+
+	return r.next.Patch(ctx, id, input, expectedVersion)
+}
+
+func (r *CachedRepo) Delete(ctx context.Context, id string) (*domain.Mower, error) {
+	defer r.invalidate(id)
+
+	return r.next.Delete(ctx, id)
+}
+
+func (r *CachedRepo) Restore(ctx context.Context, id string) (*domain.Mower, error) {
+	defer r.invalidate(id)
+
+	return r.next.Restore(ctx, id)
+}
+
+func (r *CachedRepo) Purge(ctx context.Context, id string) (*domain.Mower, error) {
+	defer r.invalidate(id)
+
+	return r.next.Purge(ctx, id)
+}
+
+func (r *CachedRepo) FindByName(ctx context.Context, name string) (*domain.Mower, error) {
+	return r.next.FindByName(ctx, name)
+}
+
+func (r *CachedRepo) FindAvailableMowers(ctx context.Context, query domain.MowerQuery) ([]*domain.Mower, error) {
+	return r.next.FindAvailableMowers(ctx, query)
+}
+
+func (r *CachedRepo) FindMowerPage(ctx context.Context, query domain.PageQuery) (*domain.MowerPage, error) {
+	return r.next.FindMowerPage(ctx, query)
+}
+
+// copyOf is copyMower for mowers that may be nil.
+func copyOf(mower *domain.Mower) *domain.Mower {
+	if mower == nil {
+		return nil
+	}
+
+	return copyMower(mower)
+}
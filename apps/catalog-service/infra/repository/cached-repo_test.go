@@ -0,0 +1,321 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	lmTesting "jrobic/lawn-mower/catalog-service"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"jrobic/lawn-mower/catalog-service/infra/idgen"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingRepo counts the lookups by id. When release is set, they answer
+// once it is closed, or fail once their context is done, with the mower as
+// it was when they started.
+type countingRepo struct {
+	*lmTesting.StubCatalogRepository
+	finds   int64
+	release chan struct{}
+}
+
+func (r *countingRepo) Find(ctx context.Context, id string) (*domain.Mower, error) {
+	mower, err := r.StubCatalogRepository.Find(ctx, id)
+	atomic.AddInt64(&r.finds, 1)
+
+	if r.release != nil {
+		select {
+		case <-r.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	return mower, err
+}
+
+func (r *countingRepo) assertFinds(t *testing.T, want int64) {
+	t.Helper()
+
+	if got := atomic.LoadInt64(&r.finds); got != want {
+		t.Errorf("got %d lookups of the repository want %d", got, want)
+	}
+}
+
+func newCountingRepo() *countingRepo {
+	return &countingRepo{StubCatalogRepository: &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{
+		{ID: "1", Name: "M-90", Version: 1},
+		{ID: "2", Name: "M-150", Version: 1},
+		{ID: "3", Name: "M-480", Version: 1},
+	}}}
+}
+
+// eventually waits for done to hold.
+func eventually(t *testing.T, done func() bool) {
+	t.Helper()
+
+	for deadline := time.Now().Add(5 * time.Second); !done(); {
+		if time.Now().After(deadline) {
+			t.Fatal("gave up waiting")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func findName(t *testing.T, repo domain.CatalogRepository, id string) string {
+	t.Helper()
+
+	mower, err := repo.Find(context.Background(), id)
+	lmTesting.AssertNoError(t, err)
+
+	if mower == nil {
+		return ""
+	}
+
+	return mower.Name
+}
+
+func TestCachedRepoContract(t *testing.T) {
+	lmTesting.RunCatalogRepositoryContract(t, func(t *testing.T, clock domain.Clock) domain.CatalogRepository {
+		next := NewInMemoryRepo([]*domain.Mower{}, WithClock(clock), WithIDGenerator(idgen.NewSequential(0)))
+
+		return NewCachedRepo(next, CacheOptions{NegativeTTL: time.Minute}, WithClock(clock))
+	})
+}
+
+func TestCachedRepo(t *testing.T) {
+	ctx := context.Background()
+	opts := CacheOptions{Size: 2, TTL: time.Minute, NegativeTTL: time.Second}
+
+	t.Run("serve repeated lookups from the cache", func(t *testing.T) {
+		next := newCountingRepo()
+		repo := NewCachedRepo(next, opts)
+
+		for i := 0; i < 3; i++ {
+			if got := findName(t, repo, "1"); got != "M-90" {
+				t.Errorf("got %q want M-90", got)
+			}
+		}
+
+		next.assertFinds(t, 1)
+
+		if got, want := repo.Stats(), (CacheStats{Hits: 2, Misses: 1}); got != want {
+			t.Errorf("got %+v want %+v", got, want)
+		}
+	})
+
+	t.Run("expire after the ttl", func(t *testing.T) {
+		clock := lmTesting.NewFakeClock(time.Date(2022, time.July, 14, 10, 0, 0, 0, time.UTC))
+		next := newCountingRepo()
+		repo := NewCachedRepo(next, opts, WithClock(clock))
+
+		findName(t, repo, "1")
+		clock.Advance(time.Minute - time.Nanosecond)
+		findName(t, repo, "1")
+		next.assertFinds(t, 1)
+
+		clock.Advance(time.Nanosecond)
+		findName(t, repo, "1")
+		next.assertFinds(t, 2)
+	})
+
+	t.Run("evict the least recently used", func(t *testing.T) {
+		next := newCountingRepo()
+		repo := NewCachedRepo(next, opts)
+
+		for _, id := range []string{"1", "2", "1", "3"} {
+			findName(t, repo, id)
+		}
+
+		next.assertFinds(t, 3)
+
+		findName(t, repo, "1")
+		next.assertFinds(t, 3)
+
+		findName(t, repo, "2")
+		next.assertFinds(t, 4)
+	})
+
+	t.Run("remember unknown ids for the negative ttl", func(t *testing.T) {
+		clock := lmTesting.NewFakeClock(time.Date(2022, time.July, 14, 10, 0, 0, 0, time.UTC))
+		next := newCountingRepo()
+		repo := NewCachedRepo(next, opts, WithClock(clock))
+
+		findName(t, repo, "9")
+		findName(t, repo, "9")
+		next.assertFinds(t, 1)
+
+		clock.Advance(time.Second)
+		findName(t, repo, "9")
+		next.assertFinds(t, 2)
+
+		uncached := NewCachedRepo(next, CacheOptions{})
+		findName(t, uncached, "9")
+		findName(t, uncached, "9")
+		next.assertFinds(t, 4)
+	})
+
+	t.Run("drop the mowers changed through the cache", func(t *testing.T) {
+		changes := map[string]func(repo domain.CatalogRepository) error{
+			"Patch": func(repo domain.CatalogRepository) error {
+				_, err := repo.Patch(ctx, "1", domain.UpdateMowerDTO{Name: "M-91"}, 0)
+				return err
+			},
+			"Delete": func(repo domain.CatalogRepository) error {
+				_, err := repo.Delete(ctx, "1")
+				return err
+			},
+			"Restore": func(repo domain.CatalogRepository) error {
+				_, err := repo.Restore(ctx, "1")
+				return err
+			},
+			"Purge": func(repo domain.CatalogRepository) error {
+				_, err := repo.Purge(ctx, "1")
+				return err
+			},
+		}
+
+		for name, change := range changes {
+			next := newCountingRepo()
+			repo := NewCachedRepo(next, opts)
+
+			findName(t, repo, "1")
+			lmTesting.AssertNoError(t, change(repo))
+
+			want, _ := next.StubCatalogRepository.Find(ctx, "1")
+			got, err := repo.Find(ctx, "1")
+			lmTesting.AssertNoError(t, err)
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%s: got %v want %v", name, got, want)
+			}
+
+			next.assertFinds(t, 2)
+		}
+	})
+
+	t.Run("forget an unknown id once added", func(t *testing.T) {
+		next := newCountingRepo()
+		repo := NewCachedRepo(next, opts)
+
+		findName(t, repo, "4")
+
+		_, err := repo.Add(ctx, domain.CreateMowerDTO{Name: "M-600"})
+		lmTesting.AssertNoError(t, err)
+
+		if got := findName(t, repo, "4"); got != "M-600" {
+			t.Errorf("got %q want M-600", got)
+		}
+	})
+
+	t.Run("collapse concurrent misses into one lookup", func(t *testing.T) {
+		next := newCountingRepo()
+		next.release = make(chan struct{})
+		repo := NewCachedRepo(next, opts)
+
+		const callers = 10
+
+		var wg sync.WaitGroup
+		mowers := make([]*domain.Mower, callers)
+		errs := make([]error, callers)
+
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+				mowers[i], errs[i] = repo.Find(ctx, "1")
+			}(i)
+		}
+
+		eventually(t, func() bool { return repo.Stats().Misses == callers })
+		close(next.release)
+		wg.Wait()
+
+		next.assertFinds(t, 1)
+
+		for i, mower := range mowers {
+			lmTesting.AssertNoError(t, errs[i])
+
+			if mower == nil || mower.Name != "M-90" {
+				t.Errorf("got %v want M-90", mower)
+			}
+		}
+	})
+
+	t.Run("leave out a lookup overtaken by a change", func(t *testing.T) {
+		next := newCountingRepo()
+		next.release = make(chan struct{})
+		repo := NewCachedRepo(next, opts)
+
+		found := make(chan *domain.Mower)
+
+		go func() {
+			mower, _ := repo.Find(ctx, "1")
+			found <- mower
+		}()
+
+		eventually(t, func() bool { return atomic.LoadInt64(&next.finds) == 1 })
+
+		// changed while the lookup is in flight
+		_, err := repo.Patch(ctx, "1", domain.UpdateMowerDTO{Name: "M-91"}, 0)
+		lmTesting.AssertNoError(t, err)
+
+		close(next.release)
+		<-found
+
+		if got := findName(t, repo, "1"); got != "M-91" {
+			t.Errorf("got %q want M-91", got)
+		}
+
+		next.assertFinds(t, 2)
+	})
+
+	t.Run("outlive the caller a shared lookup was made for", func(t *testing.T) {
+		next := newCountingRepo()
+		next.release = make(chan struct{})
+		repo := NewCachedRepo(next, opts)
+
+		leaderCtx, cancel := context.WithCancel(ctx)
+		leader := make(chan error)
+
+		go func() {
+			_, err := repo.Find(leaderCtx, "1")
+			leader <- err
+		}()
+
+		eventually(t, func() bool { return atomic.LoadInt64(&next.finds) == 1 })
+
+		type result struct {
+			mower *domain.Mower
+			err   error
+		}
+
+		follower := make(chan result)
+
+		go func() {
+			mower, err := repo.Find(ctx, "1")
+			follower <- result{mower, err}
+		}()
+
+		eventually(t, func() bool { return repo.Stats().Misses == 2 })
+		cancel()
+
+		if err := <-leader; !errors.Is(err, context.Canceled) {
+			t.Errorf("got %v want %v", err, context.Canceled)
+		}
+
+		close(next.release)
+
+		got := <-follower
+		lmTesting.AssertNoError(t, got.err)
+
+		if got.mower == nil || got.mower.Name != "M-90" {
+			t.Errorf("got %v want M-90", got.mower)
+		}
+	})
+}
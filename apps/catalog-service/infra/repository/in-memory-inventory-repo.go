@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"context"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"sort"
+	"sync"
+)
+
+// InMemoryInventoryRepo keeps the units in a map by id. serials reserves
+// every serial number ever registered, retired units included, while byStore
+// and byModel index the unit ids for the store and model listings; these are
+// the only store and model indexes of the in-memory backend. One RWMutex
+// guards the units and the three indexes. Linked to a catalog, see
+// WithCatalog, Add reads the model under the catalog read lock and holds it
+// while storing the unit, and the catalog calls holds under its write lock,
+// so the catalog lock is always taken first.
+type InMemoryInventoryRepo struct {
+	lock  sync.RWMutex
+	units map[string]*domain.InventoryUnit
+	// position orders the units as they were added, the order of listings.
+	position map[string]int
+	added    int
+	serials  map[string]string
+	byStore  map[string]map[string]struct{}
+	byModel  map[string]map[string]struct{}
+	clock    domain.Clock
+	ids      domain.IDGenerator
+	catalog  *InMemoryRepo
+}
+
+func NewInMemoryInventoryRepo(initialUnits []*domain.InventoryUnit, opts ...Option) *InMemoryInventoryRepo {
+	o := newOptions(opts)
+
+	r := &InMemoryInventoryRepo{
+		units:    map[string]*domain.InventoryUnit{},
+		position: map[string]int{},
+		serials:  map[string]string{},
+		byStore:  map[string]map[string]struct{}{},
+		byModel:  map[string]map[string]struct{}{},
+		clock:    o.clock,
+		ids:      o.ids,
+		catalog:  o.catalog,
+	}
+
+	for _, unit := range initialUnits {
+		r.put(copyUnit(unit))
+	}
+
+	if r.catalog != nil {
+		r.catalog.lock.Lock()
+		r.catalog.inventory = r
+		r.catalog.lock.Unlock()
+	}
+
+	return r
+}
+
+func (r *InMemoryInventoryRepo) Add(ctx context.Context, input domain.RegisterUnitDTO) (*domain.InventoryUnit, error) {
+	if r.catalog != nil {
+		// the model cannot be deleted until the unit is stored
+		r.catalog.lock.RLock()
+		defer r.catalog.lock.RUnlock()
+
+		if mower, ok := r.catalog.mowers[input.ModelID]; !ok || mower.DeletedAt != nil {
+			return nil, domain.MowerNotFound(input.ModelID)
+		}
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, taken := r.serials[input.SerialNumber]; taken {
+		return nil, domain.ErrSerialNumberTaken
+	}
+
+	now := domain.Stamp(r.clock)
+
+	unit := &domain.InventoryUnit{
+		ID:           r.ids.NewID(),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		SerialNumber: input.SerialNumber,
+		ModelID:      input.ModelID,
+		StoreID:      input.StoreID,
+	}
+
+	r.put(unit)
+
+	return copyUnit(unit), nil
+}
+
+func (r *InMemoryInventoryRepo) Find(ctx context.Context, id string) (*domain.InventoryUnit, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	unit, ok := r.units[id]
+
+	if !ok {
+		return nil, nil
+	}
+
+	return copyUnit(unit), nil
+}
+
+func (r *InMemoryInventoryRepo) Retire(ctx context.Context, id string) (*domain.InventoryUnit, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	current, ok := r.units[id]
+
+	if !ok {
+		return nil, nil
+	}
+
+	if current.DeletedAt != nil {
+		return copyUnit(current), nil
+	}
+
+	unit := copyUnit(current)
+	now := domain.Stamp(r.clock)
+	unit.DeletedAt = now
+	unit.UpdatedAt = now
+
+	r.put(unit)
+
+	return copyUnit(unit), nil
+}
+
+func (r *InMemoryInventoryRepo) Move(ctx context.Context, id string, storeID string) (*domain.InventoryUnit, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	current, ok := r.units[id]
+
+	if !ok {
+		return nil, nil
+	}
+
+	unit := copyUnit(current)
+	unit.StoreID = storeID
+	unit.UpdatedAt = domain.Stamp(r.clock)
+
+	r.put(unit)
+
+	return copyUnit(unit), nil
+}
+
+func (r *InMemoryInventoryRepo) FindByStore(ctx context.Context, storeID string) ([]*domain.InventoryUnit, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.findLive(r.byStore[storeID]), nil
+}
+
+func (r *InMemoryInventoryRepo) FindByModel(ctx context.Context, modelID string) ([]*domain.InventoryUnit, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.findLive(r.byModel[modelID]), nil
+}
+
+func (r *InMemoryInventoryRepo) FindStockedModelIDs(ctx context.Context, storeID string) ([]string, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	seen := map[string]bool{}
+	modelIDs := []string{}
+
+	for id := range r.byStore[storeID] {
+		unit := r.units[id]
+
+		if unit.DeletedAt == nil && !seen[unit.ModelID] {
+			seen[unit.ModelID] = true
+			modelIDs = append(modelIDs, unit.ModelID)
+		}
+	}
+
+	sort.Strings(modelIDs)
+
+	return modelIDs, nil
+}
+
+// holds reports whether live units, or retired ones too when retired is set,
+// are of the model. The catalog calls it under its write lock.
+func (r *InMemoryInventoryRepo) holds(modelID string, retired bool) bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	for id := range r.byModel[modelID] {
+		if retired || r.units[id].DeletedAt == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// findLive returns copies of the live units among ids, in the order they
+// were added. The caller holds the read lock.
+func (r *InMemoryInventoryRepo) findLive(ids map[string]struct{}) []*domain.InventoryUnit {
+	units := []*domain.InventoryUnit{}
+
+	for id := range ids {
+		if unit := r.units[id]; unit.DeletedAt == nil {
+			units = append(units, copyUnit(unit))
+		}
+	}
+
+	sort.Slice(units, func(i, j int) bool {
+		return r.position[units[i].ID] < r.position[units[j].ID]
+	})
+
+	return units
+}
+
+// put stores unit in place of the unit with the same id, if any, and
+// updates the indexes. The caller holds the write lock.
+func (r *InMemoryInventoryRepo) put(unit *domain.InventoryUnit) {
+	if previous, ok := r.units[unit.ID]; ok {
+		unindex(r.byStore, previous.StoreID, previous.ID)
+		unindex(r.byModel, previous.ModelID, previous.ID)
+	} else {
+		r.added++
+		r.position[unit.ID] = r.added
+	}
+
+	r.units[unit.ID] = unit
+	r.serials[unit.SerialNumber] = unit.ID
+	index(r.byStore, unit.StoreID, unit.ID)
+	index(r.byModel, unit.ModelID, unit.ID)
+}
+
+// copyUnit returns a copy of unit sharing nothing with it.
+func copyUnit(unit *domain.InventoryUnit) *domain.InventoryUnit {
+	copied := *unit
+	copied.CreatedAt = copyTimestamp(unit.CreatedAt)
+	copied.UpdatedAt = copyTimestamp(unit.UpdatedAt)
+	copied.DeletedAt = copyTimestamp(unit.DeletedAt)
+
+	return &copied
+}
@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	lmTesting "jrobic/lawn-mower/catalog-service"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"jrobic/lawn-mower/catalog-service/infra/idgen"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestInMemoryInventoryRepo(t *testing.T) {
+	now := time.Date(2022, time.July, 14, 10, 0, 0, 0, time.UTC)
+	clock := lmTesting.NewFakeClock(now)
+	clock.Step = time.Minute
+
+	repo := NewInMemoryInventoryRepo(
+		[]*domain.InventoryUnit{},
+		WithClock(clock),
+		WithIDGenerator(idgen.NewSequential(0)),
+	)
+
+	added, err := repo.Add(context.Background(), domain.RegisterUnitDTO{SerialNumber: "SN-1", ModelID: "M1", StoreID: "S1"})
+	lmTesting.AssertNoError(t, err)
+
+	want := domain.InventoryUnit{
+		ID:           "1",
+		CreatedAt:    domain.NewTimestamp(now),
+		UpdatedAt:    domain.NewTimestamp(now),
+		SerialNumber: "SN-1",
+		ModelID:      "M1",
+		StoreID:      "S1",
+	}
+
+	if !reflect.DeepEqual(*added, want) {
+		t.Errorf("got %v want %v", added, want)
+	}
+
+	_, err = repo.Add(context.Background(), domain.RegisterUnitDTO{SerialNumber: "SN-2", ModelID: "M2", StoreID: "S1"})
+	lmTesting.AssertNoError(t, err)
+
+	_, err = repo.Add(context.Background(), domain.RegisterUnitDTO{SerialNumber: "SN-3", ModelID: "M1", StoreID: "S1"})
+	lmTesting.AssertNoError(t, err)
+
+	t.Run("serial numbers are unique", func(t *testing.T) {
+		_, err := repo.Add(context.Background(), domain.RegisterUnitDTO{SerialNumber: "SN-1", ModelID: "M2", StoreID: "S2"})
+
+		if !errors.Is(err, domain.ErrSerialNumberTaken) {
+			t.Errorf("got %v want %v", err, domain.ErrSerialNumberTaken)
+		}
+	})
+
+	t.Run("list stocked models once", func(t *testing.T) {
+		got, err := repo.FindStockedModelIDs(context.Background(), "S1")
+		lmTesting.AssertNoError(t, err)
+
+		if !reflect.DeepEqual(got, []string{"M1", "M2"}) {
+			t.Errorf("got %v want [M1 M2]", got)
+		}
+	})
+
+	t.Run("move unit to another store", func(t *testing.T) {
+		moved, err := repo.Move(context.Background(), "1", "S2")
+		lmTesting.AssertNoError(t, err)
+
+		if moved.StoreID != "S2" || reflect.DeepEqual(moved.UpdatedAt, moved.CreatedAt) {
+			t.Errorf("got %v want unit moved to S2 with a new UpdatedAt", moved)
+		}
+
+		units, _ := repo.FindByStore(context.Background(), "S2")
+
+		if len(units) != 1 || units[0].ID != "1" {
+			t.Errorf("got %v want unit #1 at S2", units)
+		}
+	})
+
+	t.Run("retired units keep their serial number but are not listed", func(t *testing.T) {
+		retired, err := repo.Retire(context.Background(), "3")
+		lmTesting.AssertNoError(t, err)
+
+		if retired.DeletedAt == nil {
+			t.Fatalf("expected DeletedAt to be set")
+		}
+
+		units, _ := repo.FindByModel(context.Background(), "M1")
+
+		if len(units) != 1 || units[0].ID != "1" {
+			t.Errorf("got %v want only unit #1", units)
+		}
+
+		_, err = repo.Add(context.Background(), domain.RegisterUnitDTO{SerialNumber: "SN-3", ModelID: "M1", StoreID: "S1"})
+
+		if !errors.Is(err, domain.ErrSerialNumberTaken) {
+			t.Errorf("got %v want %v", err, domain.ErrSerialNumberTaken)
+		}
+	})
+
+	t.Run("unknown unit returns nothing", func(t *testing.T) {
+		if got, _ := repo.Retire(context.Background(), "6"); got != nil {
+			t.Errorf("expected no unit, got %v", got)
+		}
+
+		if got, _ := repo.Move(context.Background(), "6", "S1"); got != nil {
+			t.Errorf("expected no unit, got %v", got)
+		}
+	})
+}
+
+// TestInMemoryInventoryRepoConcurrency is meant for go test -race: units move
+// between stores while the stores are listed, and the indexes must end up
+// listing every unit in exactly one store.
+func TestInMemoryInventoryRepoConcurrency(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryInventoryRepo([]*domain.InventoryUnit{}, WithIDGenerator(idgen.NewSequential(0)))
+	stores := []string{"S1", "S2", "S3"}
+
+	for i := 0; i < 30; i++ {
+		_, err := repo.Add(ctx, domain.RegisterUnitDTO{SerialNumber: fmt.Sprint("SN-", i), ModelID: fmt.Sprint("M", i%4), StoreID: stores[i%3]})
+		lmTesting.AssertNoError(t, err)
+	}
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < 8; w++ {
+		wg.Add(2)
+
+		go func(w int) {
+			defer wg.Done()
+
+			for i := 0; i < 100; i++ {
+				if _, err := repo.Move(ctx, fmt.Sprint(i%30+1), stores[(i+w)%3]); err != nil {
+					t.Errorf("unexpected error %v", err)
+					return
+				}
+			}
+		}(w)
+
+		go func(w int) {
+			defer wg.Done()
+
+			for i := 0; i < 100; i++ {
+				units, err := repo.FindByStore(ctx, stores[i%3])
+
+				if err != nil {
+					t.Errorf("unexpected error %v", err)
+					return
+				}
+
+				for _, unit := range units {
+					unit.StoreID = "changed by a reader"
+				}
+
+				if _, err := repo.FindStockedModelIDs(ctx, stores[w%3]); err != nil {
+					t.Errorf("unexpected error %v", err)
+					return
+				}
+			}
+		}(w)
+	}
+
+	wg.Wait()
+
+	listed := map[string]string{}
+
+	for _, store := range stores {
+		units, _ := repo.FindByStore(ctx, store)
+
+		for _, unit := range units {
+			if other, ok := listed[unit.ID]; ok {
+				t.Errorf("unit #%s listed at %s and %s", unit.ID, other, store)
+			}
+
+			if unit.StoreID != store {
+				t.Errorf("unit #%s at %s listed at %s", unit.ID, unit.StoreID, store)
+			}
+
+			listed[unit.ID] = store
+		}
+	}
+
+	if len(listed) != 30 {
+		t.Errorf("got %d units listed want 30", len(listed))
+	}
+}
+
+func TestInMemoryInventoryRepoContract(t *testing.T) {
+	lmTesting.RunInventoryRepositoryContract(t, func(t *testing.T) lmTesting.InventoryRepositories {
+		ids := idgen.NewSequential(0)
+		catalog := NewInMemoryRepo([]*domain.Mower{}, WithIDGenerator(ids))
+
+		return lmTesting.InventoryRepositories{
+			Catalog:   catalog,
+			Stores:    NewInMemoryStoreRepo([]*domain.Store{}, WithIDGenerator(ids)),
+			Inventory: NewInMemoryInventoryRepo([]*domain.InventoryUnit{}, WithIDGenerator(ids), WithCatalog(catalog)),
+		}
+	})
+}
+
+func TestInMemoryInventoryRepoCatalog(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("never leave a unit of a deleted model", func(t *testing.T) {
+		for round := 0; round < 20; round++ {
+			catalog := NewInMemoryRepo([]*domain.Mower{{ID: "M1", Name: "M-90"}})
+			repo := NewInMemoryInventoryRepo([]*domain.InventoryUnit{}, WithCatalog(catalog))
+
+			var wg sync.WaitGroup
+			errs := make(chan error, 9)
+
+			for w := 0; w < 8; w++ {
+				wg.Add(1)
+
+				go func(w int) {
+					defer wg.Done()
+
+					_, err := repo.Add(ctx, domain.RegisterUnitDTO{SerialNumber: fmt.Sprint("SN-", w), ModelID: "M1", StoreID: "S1"})
+
+					if err != nil && err.Error() != domain.MowerNotFound("M1").Error() {
+						errs <- err
+					}
+				}(w)
+			}
+
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				if _, err := catalog.Delete(ctx, "M1"); err != nil && !errors.Is(err, domain.ErrMowerHasInventory) {
+					errs <- err
+				}
+			}()
+
+			wg.Wait()
+			close(errs)
+
+			for err := range errs {
+				t.Errorf("unexpected error %v", err)
+			}
+
+			mower, _ := catalog.Find(ctx, "M1")
+			units, _ := repo.FindByModel(ctx, "M1")
+
+			if mower.DeletedAt != nil && len(units) > 0 {
+				t.Fatalf("round %d: deleted model M1 still has units %v", round, units)
+			}
+		}
+	})
+}
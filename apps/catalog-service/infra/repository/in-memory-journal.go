@@ -0,0 +1,500 @@
+package repository
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// SyncPolicy tells when a Journal flushes its writes to disk with fsync.
+type SyncPolicy string
+
+const (
+	// SyncAlways flushes every change before it is acknowledged, so that a
+	// crash never loses an acknowledged change.
+	SyncAlways SyncPolicy = "always"
+	// SyncInterval flushes at most once per JournalOptions.SyncInterval, on
+	// the next change, on InMemoryRepo.FlushJournal or on Close: a power
+	// loss may lose the changes of the last interval.
+	SyncInterval SyncPolicy = "interval"
+	// SyncNever leaves flushing to the operating system.
+	SyncNever SyncPolicy = "never"
+)
+
+const (
+	DefaultSyncInterval  = time.Second
+	DefaultSnapshotEvery = 1000
+
+	snapshotFile = "snapshot.json"
+	logFile      = "journal.log"
+
+	opPut   = "put"
+	opPurge = "purge"
+)
+
+// SyncPolicies lists the valid SyncPolicy values.
+func SyncPolicies() []SyncPolicy {
+	return []SyncPolicy{SyncAlways, SyncInterval, SyncNever}
+}
+
+type JournalOptions struct {
+	Sync SyncPolicy
+	// SyncInterval defaults to DefaultSyncInterval.
+	SyncInterval time.Duration
+	// SnapshotEvery compacts the log into a new snapshot once it holds that
+	// many records, DefaultSnapshotEvery when zero.
+	SnapshotEvery int
+}
+
+// Journal persists the mowers of an InMemoryRepo in a directory: a JSON
+// snapshot of every mower, and an append-only log of the changes made since.
+// Every log record is a line holding the CRC-32 of its JSON then the JSON,
+// so that a record torn by a crash is detected and dropped on recovery.
+//
+// A failed fsync leaves the journal failed: the operating system may have
+// dropped the writes it could not flush, so the journal refuses every
+// change past it rather than acknowledge changes it cannot vouch for.
+type Journal struct {
+	dir      string
+	opts     JournalOptions
+	log      journalLog
+	size     int64
+	seq      uint64
+	records  int
+	lastSync time.Time
+	created  bool
+	failed   error
+	// unsynced tells whether the log was written since the last fsync. Like
+	// every field it is only read and written under the write lock of the
+	// repository, which FlushJournal takes as well.
+	unsynced bool
+	mowers   []*domain.Mower
+}
+
+// journalLog is the log file, an *os.File but in tests.
+type journalLog interface {
+	io.ReadWriteCloser
+	WriteString(s string) (int, error)
+	Truncate(size int64) error
+	Sync() error
+}
+
+// journalRecord is a line of the log. Seq orders the records across
+// snapshots: the ones the snapshot already holds are skipped on recovery.
+type journalRecord struct {
+	Seq   uint64       `json:"seq"`
+	Op    string       `json:"op"`
+	Mower *storedMower `json:"mower,omitempty"`
+	ID    string       `json:"id,omitempty"`
+}
+
+type journalSnapshot struct {
+	Seq    uint64         `json:"seq"`
+	Mowers []*storedMower `json:"mowers"`
+}
+
+// storedMower is a mower as the journal writes it, with its timestamps at
+// full precision rather than the unix seconds of the API.
+type storedMower struct {
+	ID        string            `json:"id"`
+	CreatedAt *time.Time        `json:"createdAt,omitempty"`
+	UpdatedAt *time.Time        `json:"updatedAt,omitempty"`
+	DeletedAt *time.Time        `json:"deletedAt,omitempty"`
+	Version   int64             `json:"version"`
+	Name      string            `json:"name"`
+	Specs     domain.MowerSpecs `json:"specs"`
+}
+
+// OpenJournal recovers the mowers persisted in dir, creating it if needed.
+// A torn record at the end of the log, left by a crash in the middle of a
+// write, is truncated; a damaged record anywhere else is an error.
+func OpenJournal(dir string, opts JournalOptions) (*Journal, error) {
+	if opts.Sync == "" {
+		opts.Sync = SyncAlways
+	}
+
+	if opts.SyncInterval <= 0 {
+		opts.SyncInterval = DefaultSyncInterval
+	}
+
+	if opts.SnapshotEvery <= 0 {
+		opts.SnapshotEvery = DefaultSnapshotEvery
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	j := &Journal{dir: dir, opts: opts, lastSync: time.Now()}
+	mowers := map[string]*domain.Mower{}
+
+	snapshotFound, err := j.readSnapshot(mowers)
+
+	if err != nil {
+		return nil, err
+	}
+
+	j.log, err = os.OpenFile(filepath.Join(dir, logFile), os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := j.replay(mowers); err != nil {
+		j.log.Close()
+		return nil, err
+	}
+
+	j.created = !snapshotFound && j.size == 0
+
+	for _, mower := range mowers {
+		j.mowers = append(j.mowers, mower)
+	}
+
+	sort.Slice(j.mowers, func(a, b int) bool { return j.mowers[a].ID < j.mowers[b].ID })
+
+	return j, nil
+}
+
+// Created reports whether the journal started empty, without a snapshot or
+// a log to recover.
+func (j *Journal) Created() bool {
+	return j.created
+}
+
+// Mowers returns the mowers recovered by OpenJournal.
+func (j *Journal) Mowers() []*domain.Mower {
+	return j.mowers
+}
+
+func (j *Journal) readSnapshot(mowers map[string]*domain.Mower) (bool, error) {
+	content, err := os.ReadFile(filepath.Join(j.dir, snapshotFile))
+
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	var snapshot journalSnapshot
+
+	if err := json.Unmarshal(content, &snapshot); err != nil {
+		return false, fmt.Errorf("%s: %w", snapshotFile, err)
+	}
+
+	for _, stored := range snapshot.Mowers {
+		mowers[stored.ID] = stored.mower()
+	}
+
+	j.seq = snapshot.Seq
+
+	return true, nil
+}
+
+// replay applies the records of the log newer than the snapshot.
+func (j *Journal) replay(mowers map[string]*domain.Mower) error {
+	reader := bufio.NewReader(j.log)
+
+	for {
+		line, err := reader.ReadBytes('\n')
+
+		if errors.Is(err, io.EOF) && len(line) == 0 {
+			return nil
+		}
+
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		record, decodeErr := decodeRecord(line)
+
+		if decodeErr != nil {
+			if _, peekErr := reader.Peek(1); !errors.Is(peekErr, io.EOF) {
+				return fmt.Errorf("%s: damaged record at offset %d: %w", logFile, j.size, decodeErr)
+			}
+
+			// the last record was torn by a crash, drop it for good: left in
+			// the page cache only, the next records could follow it on disk
+			if err := j.log.Truncate(j.size); err != nil {
+				return err
+			}
+
+			return j.log.Sync()
+		}
+
+		j.size += int64(len(line))
+		j.records++
+
+		if record.Seq <= j.seq {
+			continue
+		}
+
+		j.seq = record.Seq
+
+		switch record.Op {
+		case opPut:
+			mowers[record.Mower.ID] = record.Mower.mower()
+		case opPurge:
+			delete(mowers, record.ID)
+		}
+	}
+}
+
+func decodeRecord(line []byte) (*journalRecord, error) {
+	if len(line) == 0 || line[len(line)-1] != '\n' {
+		return nil, errors.New("incomplete record")
+	}
+
+	checksum, body, found := bytes.Cut(bytes.TrimSuffix(line, []byte("\n")), []byte(" "))
+
+	if !found {
+		return nil, errors.New("missing checksum")
+	}
+
+	want, err := strconv.ParseUint(string(checksum), 16, 32)
+
+	if err != nil || uint32(want) != crc32.ChecksumIEEE(body) {
+		return nil, errors.New("checksum mismatch")
+	}
+
+	var record journalRecord
+
+	if err := json.Unmarshal(body, &record); err != nil {
+		return nil, err
+	}
+
+	if (record.Op != opPut || record.Mower == nil) && (record.Op != opPurge || record.ID == "") {
+		return nil, fmt.Errorf("unknown operation %q", record.Op)
+	}
+
+	return &record, nil
+}
+
+func (j *Journal) put(mower *domain.Mower) error {
+	return j.append(journalRecord{Op: opPut, Mower: newStoredMower(mower)})
+}
+
+func (j *Journal) purge(id string) error {
+	return j.append(journalRecord{Op: opPurge, ID: id})
+}
+
+// append writes record at the end of the log, removing whatever part of it
+// got written when the write or its fsync fails.
+func (j *Journal) append(record journalRecord) error {
+	if j.failed != nil {
+		return fmt.Errorf("journal refuses changes since fsync failed: %w", j.failed)
+	}
+
+	record.Seq = j.seq + 1
+
+	body, err := json.Marshal(record)
+
+	if err != nil {
+		return err
+	}
+
+	line := fmt.Sprintf("%08x %s\n", crc32.ChecksumIEEE(body), body)
+
+	if _, err := j.log.WriteString(line); err != nil {
+		if truncErr := j.log.Truncate(j.size); truncErr != nil {
+			return fmt.Errorf("%w, then the log could not be repaired: %v", err, truncErr)
+		}
+
+		return err
+	}
+
+	// cleared by the fsync below, if one is due
+	j.unsynced = true
+
+	if j.opts.Sync == SyncAlways || (j.opts.Sync == SyncInterval && time.Since(j.lastSync) >= j.opts.SyncInterval) {
+		if err := j.sync(); err != nil {
+			if truncErr := j.log.Truncate(j.size); truncErr != nil {
+				return fmt.Errorf("%w, then the log could not be repaired: %v", err, truncErr)
+			}
+
+			return err
+		}
+	}
+
+	j.size += int64(len(line))
+	j.seq = record.Seq
+	j.records++
+
+	return nil
+}
+
+// flush syncs the writes made since the last fsync, if any.
+func (j *Journal) flush() error {
+	if !j.unsynced {
+		return j.failed
+	}
+
+	return j.sync()
+}
+
+// sync flushes the log, failing the journal for good when it cannot.
+func (j *Journal) sync() error {
+	if j.failed != nil {
+		return j.failed
+	}
+
+	j.lastSync = time.Now()
+
+	if err := j.log.Sync(); err != nil {
+		j.failed = err
+		return err
+	}
+
+	j.unsynced = false
+
+	return nil
+}
+
+// snapshotDue reports whether the log got long enough to be compacted.
+func (j *Journal) snapshotDue() bool {
+	return j.records >= j.opts.SnapshotEvery
+}
+
+// snapshot writes mowers, the state after the last record, as the new
+// snapshot then empties the log. The snapshot replaces the previous one
+// atomically: a crash leaves either of them, and the log records the
+// snapshot holds are skipped on recovery.
+func (j *Journal) snapshot(mowers []*domain.Mower) error {
+	if j.failed != nil {
+		return j.failed
+	}
+
+	snapshot := journalSnapshot{Seq: j.seq, Mowers: make([]*storedMower, len(mowers))}
+
+	for i, mower := range mowers {
+		snapshot.Mowers[i] = newStoredMower(mower)
+	}
+
+	sort.Slice(snapshot.Mowers, func(a, b int) bool { return snapshot.Mowers[a].ID < snapshot.Mowers[b].ID })
+
+	content, err := json.MarshalIndent(snapshot, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(j.dir, snapshotFile)
+
+	if err := writeFileSync(path+".tmp", content); err != nil {
+		return err
+	}
+
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return err
+	}
+
+	if err := syncDir(j.dir); err != nil {
+		return err
+	}
+
+	if err := j.log.Truncate(0); err != nil {
+		return err
+	}
+
+	j.size = 0
+	j.records = 0
+
+	return j.sync()
+}
+
+func (j *Journal) close() error {
+	err := j.sync()
+
+	if closeErr := j.log.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+func writeFileSync(path string, content []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// syncDir makes a rename in dir durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+
+	if err != nil {
+		return err
+	}
+
+	defer d.Close()
+
+	return d.Sync()
+}
+
+func newStoredMower(mower *domain.Mower) *storedMower {
+	return &storedMower{
+		ID:        mower.ID,
+		CreatedAt: timeOf(mower.CreatedAt),
+		UpdatedAt: timeOf(mower.UpdatedAt),
+		DeletedAt: timeOf(mower.DeletedAt),
+		Version:   mower.Version,
+		Name:      mower.Name,
+		Specs:     mower.Specs,
+	}
+}
+
+func (s *storedMower) mower() *domain.Mower {
+	return &domain.Mower{
+		ID:        s.ID,
+		CreatedAt: timestampOf(s.CreatedAt),
+		UpdatedAt: timestampOf(s.UpdatedAt),
+		DeletedAt: timestampOf(s.DeletedAt),
+		Version:   s.Version,
+		Name:      s.Name,
+		Specs:     s.Specs,
+	}
+}
+
+func timeOf(t *domain.Timestamp) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	converted := time.Time(*t)
+
+	return &converted
+}
+
+func timestampOf(t *time.Time) *domain.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return domain.NewTimestamp(*t)
+}
@@ -0,0 +1,325 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	lmTesting "jrobic/lawn-mower/catalog-service"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"jrobic/lawn-mower/catalog-service/infra/idgen"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// openJournaledRepo opens the repository persisted in dir, as a restarted
+// service would.
+func openJournaledRepo(t *testing.T, dir string, opts JournalOptions, clock domain.Clock) (*InMemoryRepo, *Journal) {
+	t.Helper()
+
+	journal, err := OpenJournal(dir, opts)
+	lmTesting.AssertNoError(t, err)
+
+	t.Cleanup(func() { journal.log.Close() })
+
+	return NewInMemoryRepo(nil, WithJournal(journal), WithClock(clock), WithIDGenerator(idgen.NewSequential(0))), journal
+}
+
+func allMowers(t *testing.T, repo *InMemoryRepo) []*domain.Mower {
+	t.Helper()
+
+	page, err := repo.FindMowerPage(context.Background(), domain.PageQuery{Limit: 100, MowerQuery: domain.MowerQuery{Availability: domain.AnyAvailability}})
+	lmTesting.AssertNoError(t, err)
+
+	return page.Mowers
+}
+
+func assertSameMowers(t *testing.T, got, want []*domain.Mower) {
+	t.Helper()
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+// changeMowers makes a few changes of every kind, leaving mowers #2 and #4.
+func changeMowers(t *testing.T, repo *InMemoryRepo) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	for _, name := range []string{"M-90", "M-150", "M-480"} {
+		_, err := repo.Add(ctx, domain.CreateMowerDTO{Name: name, Specs: domain.MowerSpecs{CuttingWidthCm: 42}})
+		lmTesting.AssertNoError(t, err)
+	}
+
+	_, err := repo.Patch(ctx, "2", domain.UpdateMowerDTO{Name: "M-151"}, 1)
+	lmTesting.AssertNoError(t, err)
+
+	_, err = repo.Delete(ctx, "3")
+	lmTesting.AssertNoError(t, err)
+
+	_, err = repo.Purge(ctx, "1")
+	lmTesting.AssertNoError(t, err)
+
+	_, err = repo.Add(ctx, domain.CreateMowerDTO{Name: "M-900"})
+	lmTesting.AssertNoError(t, err)
+
+	_, err = repo.Purge(ctx, "3")
+	lmTesting.AssertNoError(t, err)
+}
+
+func TestJournal(t *testing.T) {
+	clock := lmTesting.NewFakeClock(time.Date(2022, time.July, 14, 10, 0, 0, 123456000, time.UTC))
+	clock.Step = time.Minute
+
+	t.Run("recover the mowers after a crash", func(t *testing.T) {
+		dir := t.TempDir()
+
+		repo, journal := openJournaledRepo(t, dir, JournalOptions{}, clock)
+
+		if !journal.Created() {
+			t.Error("expected a new journal")
+		}
+
+		changeMowers(t, repo)
+		want := allMowers(t, repo)
+
+		recovered, journal := openJournaledRepo(t, dir, JournalOptions{}, clock)
+
+		if journal.Created() {
+			t.Error("expected the journal to be recovered")
+		}
+
+		assertSameMowers(t, allMowers(t, recovered), want)
+
+		added, _ := recovered.Add(context.Background(), domain.CreateMowerDTO{Name: "M-1000"})
+
+		if added.ID != "5" {
+			t.Errorf("got id %q want 5, ids must not be handed out twice", added.ID)
+		}
+	})
+
+	t.Run("compact the log into a snapshot", func(t *testing.T) {
+		dir := t.TempDir()
+		opts := JournalOptions{SnapshotEvery: 3}
+
+		repo, journal := openJournaledRepo(t, dir, opts, clock)
+		changeMowers(t, repo)
+		want := allMowers(t, repo)
+
+		if journal.records != 2 {
+			t.Errorf("got %d records in the log want the 2 made since the last snapshot", journal.records)
+		}
+
+		recovered, _ := openJournaledRepo(t, dir, opts, clock)
+
+		assertSameMowers(t, allMowers(t, recovered), want)
+	})
+
+	t.Run("close with a snapshot and an empty log", func(t *testing.T) {
+		dir := t.TempDir()
+
+		repo, _ := openJournaledRepo(t, dir, JournalOptions{}, clock)
+		changeMowers(t, repo)
+		want := allMowers(t, repo)
+
+		lmTesting.AssertNoError(t, repo.Close())
+
+		if info, err := os.Stat(filepath.Join(dir, logFile)); err != nil || info.Size() != 0 {
+			t.Errorf("got %v, %v want an empty log", info, err)
+		}
+
+		recovered, _ := openJournaledRepo(t, dir, JournalOptions{}, clock)
+
+		assertSameMowers(t, allMowers(t, recovered), want)
+	})
+
+	t.Run("truncate a torn last record", func(t *testing.T) {
+		dir := t.TempDir()
+
+		repo, journal := openJournaledRepo(t, dir, JournalOptions{}, clock)
+		changeMowers(t, repo)
+		want := allMowers(t, repo)
+		size := journal.size
+
+		appendToLog(t, dir, `1c291ca3 {"seq":9,"op":"put","mower":{"id":"9","na`)
+
+		recovered, journal := openJournaledRepo(t, dir, JournalOptions{}, clock)
+
+		assertSameMowers(t, allMowers(t, recovered), want)
+
+		if info, _ := os.Stat(filepath.Join(dir, logFile)); info.Size() != size {
+			t.Errorf("got a log of %d bytes want %d", info.Size(), size)
+		}
+
+		_, err := recovered.Patch(context.Background(), "2", domain.UpdateMowerDTO{Name: "M-152"}, 0)
+		lmTesting.AssertNoError(t, err)
+
+		want = allMowers(t, recovered)
+		again, _ := openJournaledRepo(t, dir, JournalOptions{}, clock)
+
+		assertSameMowers(t, allMowers(t, again), want)
+	})
+
+	t.Run("refuse a damaged record before the end of the log", func(t *testing.T) {
+		dir := t.TempDir()
+
+		repo, _ := openJournaledRepo(t, dir, JournalOptions{}, clock)
+		changeMowers(t, repo)
+
+		path := filepath.Join(dir, logFile)
+		content, _ := os.ReadFile(path)
+		damaged := strings.Replace(string(content), "M-90", "M-99", 1)
+		lmTesting.AssertNoError(t, os.WriteFile(path, []byte(damaged), 0o644))
+
+		_, err := OpenJournal(dir, JournalOptions{})
+
+		if err == nil || !strings.Contains(err.Error(), "damaged record at offset 0: checksum mismatch") {
+			t.Errorf("got %v want a damaged record error", err)
+		}
+	})
+
+	t.Run("skip the records a snapshot already holds", func(t *testing.T) {
+		dir := t.TempDir()
+
+		repo, _ := openJournaledRepo(t, dir, JournalOptions{}, clock)
+		changeMowers(t, repo)
+		want := allMowers(t, repo)
+
+		log, _ := os.ReadFile(filepath.Join(dir, logFile))
+		lmTesting.AssertNoError(t, repo.Compact())
+
+		// as if the service crashed between the snapshot and the truncation
+		appendToLog(t, dir, string(log))
+
+		recovered, _ := openJournaledRepo(t, dir, JournalOptions{}, clock)
+
+		assertSameMowers(t, allMowers(t, recovered), want)
+	})
+
+	t.Run("refuse changes once fsync failed", func(t *testing.T) {
+		dir := t.TempDir()
+		ctx := context.Background()
+
+		repo, journal := openJournaledRepo(t, dir, JournalOptions{}, clock)
+		changeMowers(t, repo)
+		want := allMowers(t, repo)
+		size, seq, records := journal.size, journal.seq, journal.records
+
+		log := &fakeSyncLog{journalLog: journal.log, failing: true}
+		journal.log = log
+
+		_, err := repo.Add(ctx, domain.CreateMowerDTO{Name: "M-1000"})
+		lmTesting.AssertError(t, err, errSyncFailed.Error())
+
+		if journal.size != size || journal.seq != seq || journal.records != records {
+			t.Errorf("got size %d, seq %d and %d records want %d, %d and %d", journal.size, journal.seq, journal.records, size, seq, records)
+		}
+
+		log.failing = false
+
+		if _, err := repo.Patch(ctx, "2", domain.UpdateMowerDTO{Name: "M-152"}, 0); err == nil {
+			t.Error("got a change journaled after a failed fsync")
+		}
+
+		assertSameMowers(t, allMowers(t, repo), want)
+
+		recovered, _ := openJournaledRepo(t, dir, JournalOptions{}, clock)
+
+		assertSameMowers(t, allMowers(t, recovered), want)
+	})
+
+	t.Run("flush the log in the background", func(t *testing.T) {
+		repo, journal := openJournaledRepo(t, t.TempDir(), JournalOptions{Sync: SyncInterval, SyncInterval: time.Hour}, clock)
+
+		log := &fakeSyncLog{journalLog: journal.log}
+		journal.log = log
+
+		ctx, cancel := context.WithCancel(context.Background())
+		flushed := make(chan struct{})
+
+		go func() {
+			repo.FlushJournal(ctx, time.Millisecond)
+			close(flushed)
+		}()
+
+		_, err := repo.Add(context.Background(), domain.CreateMowerDTO{Name: "M-90"})
+		lmTesting.AssertNoError(t, err)
+
+		for deadline := time.Now().Add(5 * time.Second); atomic.LoadInt64(&log.syncs) == 0; time.Sleep(time.Millisecond) {
+			if time.Now().After(deadline) {
+				t.Fatal("the change was never flushed")
+			}
+		}
+
+		// nothing left to flush
+		time.Sleep(20 * time.Millisecond)
+
+		if syncs := atomic.LoadInt64(&log.syncs); syncs != 1 {
+			t.Errorf("got %d fsyncs want 1", syncs)
+		}
+
+		cancel()
+		<-flushed
+	})
+
+	t.Run("leave nothing to flush after an interval fsync", func(t *testing.T) {
+		repo, journal := openJournaledRepo(t, t.TempDir(), JournalOptions{Sync: SyncInterval, SyncInterval: time.Nanosecond}, clock)
+
+		log := &fakeSyncLog{journalLog: journal.log}
+		journal.log = log
+
+		_, err := repo.Add(context.Background(), domain.CreateMowerDTO{Name: "M-90"})
+		lmTesting.AssertNoError(t, err)
+
+		lmTesting.AssertNoError(t, journal.flush())
+
+		if syncs := atomic.LoadInt64(&log.syncs); syncs != 1 {
+			t.Errorf("got %d fsyncs want 1, the append already synced the log", syncs)
+		}
+	})
+}
+
+var errSyncFailed = errors.New("input/output error")
+
+// fakeSyncLog counts its fsyncs, and fails them while failing is set, as a
+// disk would.
+type fakeSyncLog struct {
+	journalLog
+	failing bool
+	syncs   int64
+}
+
+func (l *fakeSyncLog) Sync() error {
+	if l.failing {
+		return errSyncFailed
+	}
+
+	atomic.AddInt64(&l.syncs, 1)
+
+	return l.journalLog.Sync()
+}
+
+func TestJournaledRepoContract(t *testing.T) {
+	lmTesting.RunCatalogRepositoryContract(t, func(t *testing.T, clock domain.Clock) domain.CatalogRepository {
+		repo, _ := openJournaledRepo(t, t.TempDir(), JournalOptions{SnapshotEvery: 4}, clock)
+
+		return repo
+	})
+}
+
+func appendToLog(t *testing.T, dir, content string) {
+	t.Helper()
+
+	file, err := os.OpenFile(filepath.Join(dir, logFile), os.O_WRONLY|os.O_APPEND, 0o644)
+	lmTesting.AssertNoError(t, err)
+
+	defer file.Close()
+
+	_, err = file.WriteString(content)
+	lmTesting.AssertNoError(t, err)
+}
@@ -1,70 +1,456 @@
 package repository
 
 import (
-	"fmt"
+	"context"
 	"jrobic/lawn-mower/catalog-service/domain"
+	"log"
+	"strings"
 	"sync"
+	"time"
 )
 
+// InMemoryRepo keeps the mowers in maps indexed by id and by name. A stored
+// mower is never changed in place: every change stores a new copy, and
+// callers always get copies of their own, so that nothing they do with a
+// result can race with the repository. With a journal, see WithJournal,
+// every change is written to it before being applied.
 type InMemoryRepo struct {
-	Mowers []*domain.Mower
 	lock   sync.RWMutex
+	mowers map[string]*domain.Mower
+	// names maps lower-cased names to the ids of the mowers holding them,
+	// several only in seeded catalogs.
+	names   map[string]map[string]struct{}
+	clock   domain.Clock
+	ids     domain.IDGenerator
+	journal *Journal
+	// inventory, linked by WithCatalog, holds the units that keep their
+	// model from being deleted.
+	inventory *InMemoryInventoryRepo
 }
 
-func NewInMemoryRepo(initialMowers []*domain.Mower) *InMemoryRepo {
-	mowers := []*domain.Mower{}
+func NewInMemoryRepo(initialMowers []*domain.Mower, opts ...Option) *InMemoryRepo {
+	o := newOptions(opts)
 
-	mowers = append(mowers, initialMowers...)
+	r := &InMemoryRepo{
+		mowers:  make(map[string]*domain.Mower, len(initialMowers)),
+		names:   make(map[string]map[string]struct{}, len(initialMowers)),
+		clock:   o.clock,
+		ids:     o.ids,
+		journal: o.journal,
+	}
+
+	if r.journal != nil {
+		initialMowers = r.journal.Mowers()
+	}
+
+	for _, mower := range initialMowers {
+		r.put(copyMower(mower))
+
+		if observer, ok := r.ids.(interface{ Observe(id string) }); ok {
+			observer.Observe(mower.ID)
+		}
+	}
 
-	return &InMemoryRepo{Mowers: mowers}
+	return r
 }
 
-func (r *InMemoryRepo) Add(input domain.CreateMowerDTO) (*domain.Mower, error) {
+func (r *InMemoryRepo) Add(ctx context.Context, input domain.CreateMowerDTO) (*domain.Mower, error) {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
-	id := fmt.Sprint(len(r.Mowers) + 1)
+	if r.nameTaken(input.Name, "") {
+		return nil, domain.NameTaken()
+	}
+
+	now := domain.Stamp(r.clock)
 
 	mower := &domain.Mower{
-		ID:   id,
-		Name: input.Name,
+		ID:        r.ids.NewID(),
+		CreatedAt: now,
+		UpdatedAt: now,
+		Version:   1,
+		Name:      input.Name,
+		Specs:     input.Specs,
+	}
+
+	if err := r.save(mower); err != nil {
+		return nil, err
+	}
+
+	return copyMower(mower), nil
+}
+
+func (r *InMemoryRepo) Patch(ctx context.Context, id string, input domain.UpdateMowerDTO, expectedVersion int64) (*domain.Mower, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	current, ok := r.mowers[id]
+
+	if !ok {
+		return nil, nil
+	}
+
+	if expectedVersion != 0 && current.Version != expectedVersion {
+		return nil, domain.ErrVersionConflict
+	}
+
+	if input.Name != "" && r.nameTaken(input.Name, id) {
+		return nil, domain.NameTaken()
+	}
+
+	mower := copyMower(current)
+	mower.Version++
+
+	if input.Name != "" {
+		mower.Name = input.Name
+	}
+
+	if input.Specs != nil {
+		mower.Specs = mower.Specs.Apply(*input.Specs)
+	}
+
+	mower.UpdatedAt = domain.Stamp(r.clock)
+
+	if err := r.save(mower); err != nil {
+		return nil, err
+	}
+
+	return copyMower(mower), nil
+}
+
+// nameTaken reports whether a mower other than exceptID has name, regardless
+// of case. The caller holds the write lock, so that the name cannot be taken
+// before the mower is saved.
+func (r *InMemoryRepo) nameTaken(name string, exceptID string) bool {
+	for id := range r.names[strings.ToLower(name)] {
+		if id != exceptID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *InMemoryRepo) FindByName(ctx context.Context, name string) (*domain.Mower, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var found *domain.Mower
+
+	for id := range r.names[strings.ToLower(name)] {
+		if found == nil || id < found.ID {
+			found = r.mowers[id]
+		}
+	}
+
+	if found == nil {
+		return nil, nil
+	}
+
+	return copyMower(found), nil
+}
+
+func (r *InMemoryRepo) Find(ctx context.Context, id string) (*domain.Mower, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	mower, ok := r.mowers[id]
+
+	if !ok {
+		return nil, nil
+	}
+
+	return copyMower(mower), nil
+}
+
+func (r *InMemoryRepo) Delete(ctx context.Context, id string) (*domain.Mower, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	current, ok := r.mowers[id]
+
+	if !ok {
+		return nil, nil
+	}
+
+	if current.DeletedAt != nil {
+		return copyMower(current), nil
+	}
+
+	if err := r.ensureUnstocked(id, false); err != nil {
+		return nil, err
+	}
+
+	mower := copyMower(current)
+	now := domain.Stamp(r.clock)
+	mower.DeletedAt = now
+	mower.UpdatedAt = now
+	mower.Version++
+
+	if err := r.save(mower); err != nil {
+		return nil, err
+	}
+
+	return copyMower(mower), nil
+}
+
+func (r *InMemoryRepo) Restore(ctx context.Context, id string) (*domain.Mower, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	current, ok := r.mowers[id]
+
+	if !ok {
+		return nil, nil
+	}
+
+	if current.DeletedAt == nil {
+		return copyMower(current), nil
 	}
 
-	r.Mowers = append(r.Mowers, mower)
+	mower := copyMower(current)
+	mower.DeletedAt = nil
+	mower.UpdatedAt = domain.Stamp(r.clock)
+	mower.Version++
+
+	if err := r.save(mower); err != nil {
+		return nil, err
+	}
 
-	return mower, nil
+	return copyMower(mower), nil
 }
 
-func (r *InMemoryRepo) Patch(id string, input domain.UpdateMowerDTO) (mower *domain.Mower, err error) {
+func (r *InMemoryRepo) Purge(ctx context.Context, id string) (*domain.Mower, error) {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
-	for i, mower := range r.Mowers {
-		if mower.ID == id {
-			r.Mowers[i].Name = input.Name
-			mower = r.Mowers[i]
-			return mower, err
+	mower, ok := r.mowers[id]
+
+	if !ok {
+		return nil, nil
+	}
+
+	if err := r.ensureUnstocked(id, true); err != nil {
+		return nil, err
+	}
+
+	if r.journal != nil {
+		if err := r.journal.purge(id); err != nil {
+			return nil, err
+		}
+	}
+
+	r.remove(id)
+	r.compactIfDue()
+
+	return copyMower(mower), nil
+}
+
+func (r *InMemoryRepo) FindAvailableMowers(ctx context.Context, query domain.MowerQuery) ([]*domain.Mower, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return copyMowers(domain.QueryMowers(r.candidates(query), query)), nil
+}
+
+func (r *InMemoryRepo) FindMowerPage(ctx context.Context, query domain.PageQuery) (*domain.MowerPage, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	page := domain.PageMowers(r.candidates(query.MowerQuery), query)
+	page.Mowers = copyMowers(page.Mowers)
+
+	return page, nil
+}
+
+// ensureUnstocked refuses to remove a model that live units still point at,
+// or any unit when retired is set, so that purging it never orphans the
+// serial numbers of its retired units. The caller holds the write lock.
+func (r *InMemoryRepo) ensureUnstocked(id string, retired bool) error {
+	if r.inventory != nil && r.inventory.holds(id, retired) {
+		return domain.ErrMowerHasInventory
+	}
+
+	return nil
+}
+
+// candidates returns the mowers of query.IDs when it is set, every mower
+// otherwise. The caller holds the read lock.
+func (r *InMemoryRepo) candidates(query domain.MowerQuery) []*domain.Mower {
+	if query.IDs == nil {
+		return r.all()
+	}
+
+	candidates := make([]*domain.Mower, 0, len(query.IDs))
+	picked := make(map[string]bool, len(query.IDs))
+
+	for _, id := range query.IDs {
+		if mower, ok := r.mowers[id]; ok && !picked[id] {
+			picked[id] = true
+			candidates = append(candidates, mower)
 		}
 	}
 
-	return mower, err
+	return candidates
 }
 
-func (r *InMemoryRepo) Find(id string) (mower *domain.Mower, err error) {
+// Compact writes every mower to a new snapshot of the journal and empties
+// its log. The journal compacts itself as its log grows; Compact is for
+// shutdowns and schedules.
+func (r *InMemoryRepo) Compact() error {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
-	for i, mower := range r.Mowers {
-		if mower.ID == id {
-			return r.Mowers[i], nil
+	if r.journal == nil {
+		return nil
+	}
+
+	return r.journal.snapshot(r.all())
+}
+
+// FlushJournal flushes the journal, if any, every interval until ctx is
+// done or the journal fails, so that with SyncInterval the changes reach the
+// disk within an interval even when no other change follows them.
+func (r *InMemoryRepo) FlushJournal(ctx context.Context, interval time.Duration) {
+	if r.journal == nil {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		r.lock.Lock()
+		err := r.journal.flush()
+		r.lock.Unlock()
+
+		if err != nil {
+			log.Printf("problem flushing the mower journal %v", err)
+			return
 		}
 	}
-	return nil, nil
 }
 
-func (r *InMemoryRepo) FindAvailableMowers() (mowers []*domain.Mower, err error) {
+// Close compacts then closes the journal, if any.
+func (r *InMemoryRepo) Close() error {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
-	return r.Mowers, nil
+	if r.journal == nil {
+		return nil
+	}
+
+	if err := r.journal.snapshot(r.all()); err != nil {
+		r.journal.close()
+		return err
+	}
+
+	return r.journal.close()
+}
+
+// save journals mower then stores it. The caller holds the write lock.
+func (r *InMemoryRepo) save(mower *domain.Mower) error {
+	if r.journal != nil {
+		if err := r.journal.put(mower); err != nil {
+			return err
+		}
+	}
+
+	r.put(mower)
+	r.compactIfDue()
+
+	return nil
+}
+
+// compactIfDue snapshots the journal once its log is long enough. The
+// change is durable whatever happens, so a failure is only logged: the log
+// keeps growing until a snapshot succeeds. The caller holds the write lock.
+func (r *InMemoryRepo) compactIfDue() {
+	if r.journal == nil || !r.journal.snapshotDue() {
+		return
+	}
+
+	if err := r.journal.snapshot(r.all()); err != nil {
+		log.Printf("problem compacting the mower journal %v", err)
+	}
+}
+
+func (r *InMemoryRepo) all() []*domain.Mower {
+	all := make([]*domain.Mower, 0, len(r.mowers))
+
+	for _, mower := range r.mowers {
+		all = append(all, mower)
+	}
+
+	return all
+}
+
+// put stores mower in place of the mower with the same id, if any. The
+// caller holds the write lock.
+func (r *InMemoryRepo) put(mower *domain.Mower) {
+	if previous, ok := r.mowers[mower.ID]; ok {
+		unindex(r.names, strings.ToLower(previous.Name), previous.ID)
+	}
+
+	r.mowers[mower.ID] = mower
+	index(r.names, strings.ToLower(mower.Name), mower.ID)
+}
+
+// remove forgets the mower with id. The caller holds the write lock.
+func (r *InMemoryRepo) remove(id string) {
+	unindex(r.names, strings.ToLower(r.mowers[id].Name), id)
+	delete(r.mowers, id)
+}
+
+// index adds id to the ids of key in byKey, unindex removes it.
+func index(byKey map[string]map[string]struct{}, key, id string) {
+	if byKey[key] == nil {
+		byKey[key] = map[string]struct{}{}
+	}
+
+	byKey[key][id] = struct{}{}
+}
+
+func unindex(byKey map[string]map[string]struct{}, key, id string) {
+	delete(byKey[key], id)
+
+	if len(byKey[key]) == 0 {
+		delete(byKey, key)
+	}
+}
+
+// copyMower returns a copy of mower sharing nothing with it.
+func copyMower(mower *domain.Mower) *domain.Mower {
+	copied := *mower
+	copied.CreatedAt = copyTimestamp(mower.CreatedAt)
+	copied.UpdatedAt = copyTimestamp(mower.UpdatedAt)
+	copied.DeletedAt = copyTimestamp(mower.DeletedAt)
+
+	return &copied
+}
+
+func copyMowers(mowers []*domain.Mower) []*domain.Mower {
+	copied := make([]*domain.Mower, len(mowers))
+
+	for i, mower := range mowers {
+		copied[i] = copyMower(mower)
+	}
+
+	return copied
+}
+
+func copyTimestamp(t *domain.Timestamp) *domain.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	copied := *t
+
+	return &copied
 }
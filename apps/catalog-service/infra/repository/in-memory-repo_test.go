@@ -0,0 +1,402 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	lmTesting "jrobic/lawn-mower/catalog-service"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"jrobic/lawn-mower/catalog-service/infra/idgen"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestInMemoryRepoContract(t *testing.T) {
+	lmTesting.RunCatalogRepositoryContract(t, func(t *testing.T, clock domain.Clock) domain.CatalogRepository {
+		return NewInMemoryRepo([]*domain.Mower{}, WithClock(clock), WithIDGenerator(idgen.NewSequential(0)))
+	})
+}
+
+func TestInMemoryRepoTimestamps(t *testing.T) {
+	start := time.Date(2022, time.July, 14, 10, 0, 0, 123456789, time.UTC)
+	clock := lmTesting.NewFakeClock(start)
+	clock.Step = time.Minute
+
+	repo := NewInMemoryRepo([]*domain.Mower{}, WithClock(clock))
+
+	added, err := repo.Add(context.Background(), domain.CreateMowerDTO{Name: "M-90"})
+	lmTesting.AssertNoError(t, err)
+
+	patched, err := repo.Patch(context.Background(), added.ID, domain.UpdateMowerDTO{Name: "M-150"}, 0)
+	lmTesting.AssertNoError(t, err)
+
+	lmTesting.AssertMowerEquals(t, *patched, domain.Mower{
+		ID:        added.ID,
+		Name:      "M-150",
+		CreatedAt: domain.NewTimestamp(start),
+		UpdatedAt: domain.NewTimestamp(start.Add(time.Minute)),
+		Version:   2,
+	})
+}
+
+func TestInMemoryRepoIDs(t *testing.T) {
+	repo := NewInMemoryRepo([]*domain.Mower{}, WithIDGenerator(idgen.NewSequential(0)))
+
+	first, _ := repo.Add(context.Background(), domain.CreateMowerDTO{Name: "M-90"})
+	second, _ := repo.Add(context.Background(), domain.CreateMowerDTO{Name: "M-150"})
+
+	_, err := repo.Purge(context.Background(), first.ID)
+	lmTesting.AssertNoError(t, err)
+
+	third, _ := repo.Add(context.Background(), domain.CreateMowerDTO{Name: "M-480"})
+
+	if third.ID == second.ID || third.ID != "3" {
+		t.Errorf("expected a fresh id after purge, got %q", third.ID)
+	}
+}
+
+func TestInMemoryRepoPatchVersion(t *testing.T) {
+	repo := NewInMemoryRepo([]*domain.Mower{})
+
+	added, _ := repo.Add(context.Background(), domain.CreateMowerDTO{Name: "M-90"})
+
+	var wg sync.WaitGroup
+	var succeeded int64
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			_, err := repo.Patch(context.Background(), added.ID, domain.UpdateMowerDTO{Name: fmt.Sprint("M-", i)}, 1)
+
+			if err == nil {
+				atomic.AddInt64(&succeeded, 1)
+			} else if !errors.Is(err, domain.ErrVersionConflict) {
+				t.Errorf("unexpected error %v", err)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	if succeeded != 1 {
+		t.Errorf("expected exactly one patch at version 1 to win, got %d", succeeded)
+	}
+}
+
+func TestInMemoryRepoCopies(t *testing.T) {
+	seeded := &domain.Mower{ID: "1", Name: "M-90", Version: 1}
+	repo := NewInMemoryRepo([]*domain.Mower{seeded})
+
+	seeded.Name = "changed by the seeder"
+
+	found, _ := repo.Find(context.Background(), "1")
+	found.Name = "changed by a caller"
+
+	mowers, _ := repo.FindAvailableMowers(context.Background(), domain.MowerQuery{})
+	mowers[0].Version = 42
+
+	got, _ := repo.Find(context.Background(), "1")
+
+	lmTesting.AssertMowerEquals(t, *got, domain.Mower{ID: "1", Name: "M-90", Version: 1})
+}
+
+func TestInMemoryRepoFindByName(t *testing.T) {
+	repo := NewInMemoryRepo([]*domain.Mower{{ID: "1", Name: "M-90"}, {ID: "2", Name: "m-90"}})
+
+	_, err := repo.Patch(context.Background(), "1", domain.UpdateMowerDTO{Name: "M-150"}, 0)
+	lmTesting.AssertNoError(t, err)
+
+	for name, wantID := range map[string]string{"M-150": "1", "M-90": "2"} {
+		got, _ := repo.FindByName(context.Background(), name)
+
+		if got == nil || got.ID != wantID {
+			t.Errorf("FindByName(%q) = %v want mower #%s", name, got, wantID)
+		}
+	}
+
+	_, err = repo.Purge(context.Background(), "2")
+	lmTesting.AssertNoError(t, err)
+
+	if got, _ := repo.FindByName(context.Background(), "M-90"); got != nil {
+		t.Errorf("FindByName(%q) = %v want nothing once purged", "M-90", got)
+	}
+}
+
+// TestInMemoryRepoConcurrency is meant for go test -race: readers change
+// what they get while writers change the mowers.
+func TestInMemoryRepoConcurrency(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryRepo([]*domain.Mower{}, WithIDGenerator(idgen.NewSequential(0)))
+
+	for i := 0; i < 10; i++ {
+		_, err := repo.Add(ctx, domain.CreateMowerDTO{Name: fmt.Sprint("M-", i)})
+		lmTesting.AssertNoError(t, err)
+	}
+
+	var wg sync.WaitGroup
+	var changes int64
+
+	for w := 0; w < 8; w++ {
+		wg.Add(2)
+
+		go func(w int) {
+			defer wg.Done()
+
+			for i := 0; i < 100; i++ {
+				id := fmt.Sprint(i%10 + 1)
+
+				var err error
+
+				switch i % 3 {
+				case 0:
+					_, err = repo.Patch(ctx, id, domain.UpdateMowerDTO{Specs: &domain.MowerSpecsPatch{CuttingWidthCm: &i}}, 0)
+				case 1:
+					_, err = repo.Delete(ctx, id)
+				case 2:
+					_, err = repo.Restore(ctx, id)
+				}
+
+				if err != nil {
+					t.Errorf("unexpected error %v", err)
+					return
+				}
+			}
+
+			atomic.AddInt64(&changes, 100)
+		}(w)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < 100; i++ {
+				mowers, err := repo.FindAvailableMowers(ctx, domain.MowerQuery{Availability: domain.AnyAvailability})
+
+				if err != nil {
+					t.Errorf("unexpected error %v", err)
+					return
+				}
+
+				for _, mower := range mowers {
+					mower.Name = "changed by a reader"
+					mower.Version = 0
+				}
+
+				page, err := repo.FindMowerPage(ctx, domain.PageQuery{Limit: 5, MowerQuery: domain.MowerQuery{Availability: domain.AnyAvailability}})
+
+				if err != nil {
+					t.Errorf("unexpected error %v", err)
+					return
+				}
+
+				for _, mower := range page.Mowers {
+					mower.Specs.CuttingWidthCm = -1
+				}
+
+				if found, _ := repo.Find(ctx, "1"); found != nil {
+					found.DeletedAt = nil
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	mowers, _ := repo.FindAvailableMowers(ctx, domain.MowerQuery{Availability: domain.AnyAvailability})
+
+	var versions int64
+
+	for _, mower := range mowers {
+		if mower.Name == "changed by a reader" {
+			t.Errorf("a reader changed %v", mower)
+		}
+
+		versions += mower.Version - 1
+	}
+
+	// deleting a deleted mower or restoring a live one changes nothing
+	if versions == 0 || versions > changes {
+		t.Errorf("got %d version increments for %d changes", versions, changes)
+	}
+}
+
+// sliceRepo is the InMemoryRepo this one replaced, kept to compare them: a
+// slice scanned under an exclusive lock, handing out the stored mowers.
+type sliceRepo struct {
+	mowers []*domain.Mower
+	lock   sync.Mutex
+}
+
+func (r *sliceRepo) Find(ctx context.Context, id string) (*domain.Mower, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, mower := range r.mowers {
+		if mower.ID == id {
+			return mower, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *sliceRepo) Patch(ctx context.Context, id string, input domain.UpdateMowerDTO, expectedVersion int64) (*domain.Mower, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, mower := range r.mowers {
+		if mower.ID == id {
+			mower.Version++
+			mower.Name = input.Name
+
+			return mower, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *sliceRepo) FindAvailableMowers(ctx context.Context, query domain.MowerQuery) ([]*domain.Mower, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return domain.QueryMowers(r.mowers, query), nil
+}
+
+// benchmarkedRepo is what the benchmarks call.
+type benchmarkedRepo interface {
+	Find(ctx context.Context, id string) (*domain.Mower, error)
+	Patch(ctx context.Context, id string, input domain.UpdateMowerDTO, expectedVersion int64) (*domain.Mower, error)
+	FindAvailableMowers(ctx context.Context, query domain.MowerQuery) ([]*domain.Mower, error)
+}
+
+func benchmarkRepos(b *testing.B, size int) map[string]benchmarkedRepo {
+	b.Helper()
+
+	mowers := make([]*domain.Mower, size)
+
+	for i := range mowers {
+		mowers[i] = &domain.Mower{ID: fmt.Sprint(i), Name: fmt.Sprint("M-", i), Version: 1}
+	}
+
+	return map[string]benchmarkedRepo{
+		"slice":   &sliceRepo{mowers: mowers},
+		"indexed": NewInMemoryRepo(mowers),
+	}
+}
+
+// BenchmarkInMemoryRepo compares InMemoryRepo with the slice it replaced:
+//
+//	go test -run '^$' -bench InMemoryRepo -cpu 1,8 ./infra/repository
+func BenchmarkInMemoryRepo(b *testing.B) {
+	const size = 1000
+
+	ctx := context.Background()
+
+	for name, repo := range benchmarkRepos(b, size) {
+		repo := repo
+
+		b.Run("Find/"+name, func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				for i := 0; pb.Next(); i++ {
+					if _, err := repo.Find(ctx, fmt.Sprint(i%size)); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+
+		b.Run("FindAvailableMowers/"+name, func(b *testing.B) {
+			query := domain.MowerQuery{IDs: []string{"1", "10", "100"}}
+
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, err := repo.FindAvailableMowers(ctx, query); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+
+		// one write for nine reads, like a catalog browsed more than edited
+		b.Run("Mixed/"+name, func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				for i := 0; pb.Next(); i++ {
+					id := fmt.Sprint(i % size)
+
+					var err error
+
+					if i%10 == 0 {
+						_, err = repo.Patch(ctx, id, domain.UpdateMowerDTO{Name: fmt.Sprint("M-", i)}, 0)
+					} else {
+						_, err = repo.Find(ctx, id)
+					}
+
+					if err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
+
+// BenchmarkStoreMowers lists the models of a store the way the service does,
+// through the store index of the inventory then the mowers by id, next to a
+// listing of the whole catalog: the former does not grow with the catalog.
+//
+//	go test -run '^$' -bench StoreMowers ./infra/repository
+func BenchmarkStoreMowers(b *testing.B) {
+	const stocked = 20
+
+	ctx := context.Background()
+
+	for _, size := range []int{1000, 10000} {
+		mowers := make([]*domain.Mower, size)
+		units := make([]*domain.InventoryUnit, 0, stocked*3)
+
+		for i := range mowers {
+			mowers[i] = &domain.Mower{ID: fmt.Sprint(i), Name: fmt.Sprint("M-", i)}
+		}
+
+		for i := 0; i < cap(units); i++ {
+			units = append(units, &domain.InventoryUnit{
+				ID:           fmt.Sprint(i),
+				SerialNumber: fmt.Sprint("SN-", i),
+				ModelID:      fmt.Sprint(i % stocked * (size / stocked)),
+				StoreID:      "S1",
+			})
+		}
+
+		repo := NewInMemoryRepo(mowers)
+		inventory := NewInMemoryInventoryRepo(units, WithCatalog(repo))
+
+		b.Run(fmt.Sprintf("store/%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ids, err := inventory.FindStockedModelIDs(ctx, "S1")
+
+				if err == nil {
+					_, err = repo.FindAvailableMowers(ctx, domain.MowerQuery{IDs: ids})
+				}
+
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("catalog/%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := repo.FindAvailableMowers(ctx, domain.MowerQuery{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"sync"
+)
+
+// InMemoryStoreRepo keeps the stores in a single map by id, with no other
+// index: stores are only ever looked up by id. An RWMutex guards the map;
+// Patch swaps in an updated copy under the write lock.
+type InMemoryStoreRepo struct {
+	lock   sync.RWMutex
+	stores map[string]*domain.Store
+	clock  domain.Clock
+	ids    domain.IDGenerator
+}
+
+func NewInMemoryStoreRepo(initialStores []*domain.Store, opts ...Option) *InMemoryStoreRepo {
+	o := newOptions(opts)
+
+	stores := make(map[string]*domain.Store, len(initialStores))
+
+	for _, store := range initialStores {
+		stores[store.ID] = copyStore(store)
+	}
+
+	return &InMemoryStoreRepo{stores: stores, clock: o.clock, ids: o.ids}
+}
+
+func (r *InMemoryStoreRepo) Add(ctx context.Context, input domain.CreateStoreDTO) (*domain.Store, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := domain.Stamp(r.clock)
+
+	store := &domain.Store{
+		ID:        r.ids.NewID(),
+		CreatedAt: now,
+		UpdatedAt: now,
+		Name:      input.Name,
+	}
+
+	r.stores[store.ID] = store
+
+	return copyStore(store), nil
+}
+
+func (r *InMemoryStoreRepo) Patch(ctx context.Context, id string, input domain.UpdateStoreDTO) (*domain.Store, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	current, ok := r.stores[id]
+
+	if !ok {
+		return nil, nil
+	}
+
+	store := copyStore(current)
+
+	if input.Name != "" {
+		store.Name = input.Name
+	}
+
+	store.UpdatedAt = domain.Stamp(r.clock)
+
+	r.stores[id] = store
+
+	return copyStore(store), nil
+}
+
+func (r *InMemoryStoreRepo) Find(ctx context.Context, id string) (*domain.Store, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	store, ok := r.stores[id]
+
+	if !ok {
+		return nil, nil
+	}
+
+	return copyStore(store), nil
+}
+
+// copyStore returns a copy of store sharing nothing with it.
+func copyStore(store *domain.Store) *domain.Store {
+	copied := *store
+	copied.CreatedAt = copyTimestamp(store.CreatedAt)
+	copied.UpdatedAt = copyTimestamp(store.UpdatedAt)
+	copied.DeletedAt = copyTimestamp(store.DeletedAt)
+
+	return &copied
+}
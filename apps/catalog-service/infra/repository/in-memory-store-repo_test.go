@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	lmTesting "jrobic/lawn-mower/catalog-service"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"jrobic/lawn-mower/catalog-service/infra/idgen"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestInMemoryStoreRepo(t *testing.T) {
+	now := time.Date(2022, time.July, 14, 10, 0, 0, 0, time.UTC)
+	repo := NewInMemoryStoreRepo(
+		[]*domain.Store{},
+		WithClock(lmTesting.NewFakeClock(now)),
+		WithIDGenerator(idgen.NewSequential(0)),
+	)
+
+	added, err := repo.Add(context.Background(), domain.CreateStoreDTO{Name: "Lyon"})
+	lmTesting.AssertNoError(t, err)
+
+	patched, err := repo.Patch(context.Background(), added.ID, domain.UpdateStoreDTO{})
+	lmTesting.AssertNoError(t, err)
+
+	want := domain.Store{ID: "1", Name: "Lyon", CreatedAt: domain.NewTimestamp(now), UpdatedAt: domain.NewTimestamp(now)}
+
+	if got, _ := repo.Find(context.Background(), "1"); !reflect.DeepEqual(*got, want) || !reflect.DeepEqual(*patched, want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+
+	if got, _ := repo.Patch(context.Background(), "2", domain.UpdateStoreDTO{Name: "Paris"}); got != nil {
+		t.Errorf("expected no store, got %v", got)
+	}
+}
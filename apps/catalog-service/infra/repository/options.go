@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"jrobic/lawn-mower/catalog-service/domain"
+	"jrobic/lawn-mower/catalog-service/infra/idgen"
+)
+
+type options struct {
+	clock   domain.Clock
+	ids     domain.IDGenerator
+	journal *Journal
+	catalog *InMemoryRepo
+}
+
+// Option customises how a repository is built, whatever its backend.
+type Option func(*options)
+
+// WithClock sets the clock used to stamp CreatedAt, UpdatedAt and DeletedAt.
+// It defaults to the system clock.
+func WithClock(clock domain.Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// WithIDGenerator sets how ids of new entities are made. It defaults to
+// UUIDv7; the Postgres repository only accepts UUID generators.
+func WithIDGenerator(ids domain.IDGenerator) Option {
+	return func(o *options) {
+		o.ids = ids
+	}
+}
+
+// WithJournal persists the mowers of an InMemoryRepo in journal. The
+// repository then starts from the mowers the journal recovered, ignoring its
+// initial mowers. Other repositories ignore it.
+func WithJournal(journal *Journal) Option {
+	return func(o *options) {
+		o.journal = journal
+	}
+}
+
+// WithCatalog makes an InMemoryInventoryRepo hold units of the live mowers of
+// catalog only. Registering a unit and deleting or purging its model then
+// both happen under the lock of catalog, so that no live unit is ever left
+// pointing at a deleted model. Other repositories ignore it.
+func WithCatalog(catalog *InMemoryRepo) Option {
+	return func(o *options) {
+		o.catalog = catalog
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{clock: domain.SystemClock{}, ids: idgen.UUIDv7{}}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
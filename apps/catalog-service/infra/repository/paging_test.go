@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	lmTesting "jrobic/lawn-mower/catalog-service"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestInMemoryRepoPaging(t *testing.T) {
+	clock := lmTesting.NewFakeClock(time.Time{})
+
+	assertPaging(t, NewInMemoryRepo([]*domain.Mower{}, WithClock(clock)), clock)
+}
+
+func TestPostgresRepoPaging(t *testing.T) {
+	clock := lmTesting.NewFakeClock(time.Time{})
+
+	assertPaging(t, newTestPostgresRepo(t, WithClock(clock)), clock)
+}
+
+// assertPaging checks the paging rules every CatalogRepository shares: it
+// adds mowers, two of them at the same instant, soft deletes one, then walks
+// the pages both ways.
+func assertPaging(t *testing.T, repo domain.CatalogRepository, clock *lmTesting.FakeClock) {
+	t.Helper()
+
+	start := time.Date(2022, time.July, 14, 10, 0, 0, 0, time.UTC)
+	offsets := []time.Duration{0, time.Minute, time.Minute, 2 * time.Minute, 3 * time.Minute, 4 * time.Minute}
+
+	live := []*domain.Mower{}
+
+	for i, offset := range offsets {
+		clock.Set(start.Add(offset))
+
+		mower, err := repo.Add(context.Background(), domain.CreateMowerDTO{Name: "M-" + string(rune('A'+i))})
+		lmTesting.AssertNoError(t, err)
+
+		if i == 3 {
+			_, err := repo.Delete(context.Background(), mower.ID)
+			lmTesting.AssertNoError(t, err)
+			continue
+		}
+
+		live = append(live, mower)
+	}
+
+	sort.SliceStable(live, func(i, j int) bool {
+		ti, tj := time.Time(*live[i].CreatedAt), time.Time(*live[j].CreatedAt)
+		return ti.Before(tj) || (ti.Equal(tj) && live[i].ID < live[j].ID)
+	})
+
+	want := []string{}
+
+	for _, mower := range live {
+		want = append(want, mower.ID)
+	}
+
+	t.Run("walk forward", func(t *testing.T) {
+		got := []string{}
+		query := domain.PageQuery{Limit: 2, CountTotal: true}
+
+		for pages := 0; ; pages++ {
+			page, err := repo.FindMowerPage(context.Background(), query)
+			lmTesting.AssertNoError(t, err)
+
+			if page.Total == nil || *page.Total != len(want) {
+				t.Fatalf("got total %v want %d", page.Total, len(want))
+			}
+
+			if (pages == 0) != (page.Prev == nil) {
+				t.Errorf("expected prev cursor on every page but the first, got %v on page %d", page.Prev, pages)
+			}
+
+			got = append(got, ids(page.Mowers)...)
+
+			if page.Next == nil {
+				break
+			}
+
+			query.Cursor = page.Next
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v want %v", got, want)
+		}
+	})
+
+	t.Run("walk backward from the last page", func(t *testing.T) {
+		last, err := repo.FindMowerPage(context.Background(), domain.PageQuery{Limit: 3, Cursor: domain.CursorAfter(live[1], nil)})
+		lmTesting.AssertNoError(t, err)
+
+		if last.Next != nil || !reflect.DeepEqual(ids(last.Mowers), want[2:]) {
+			t.Fatalf("got %v next %v want %v and no next", ids(last.Mowers), last.Next, want[2:])
+		}
+
+		prev, err := repo.FindMowerPage(context.Background(), domain.PageQuery{Limit: 1, Cursor: last.Prev})
+		lmTesting.AssertNoError(t, err)
+
+		if !reflect.DeepEqual(ids(prev.Mowers), want[1:2]) || prev.Prev == nil || prev.Next == nil {
+			t.Errorf("got %v prev %v next %v want %v between two pages", ids(prev.Mowers), prev.Prev, prev.Next, want[1:2])
+		}
+
+		first, err := repo.FindMowerPage(context.Background(), domain.PageQuery{Limit: 1, Cursor: prev.Prev})
+		lmTesting.AssertNoError(t, err)
+
+		if !reflect.DeepEqual(ids(first.Mowers), want[:1]) || first.Prev != nil {
+			t.Errorf("got %v prev %v want %v and no prev", ids(first.Mowers), first.Prev, want[:1])
+		}
+	})
+
+	t.Run("include deleted mowers", func(t *testing.T) {
+		page, err := repo.FindMowerPage(context.Background(), domain.PageQuery{Limit: 10, MowerQuery: domain.MowerQuery{Availability: domain.AnyAvailability}})
+		lmTesting.AssertNoError(t, err)
+
+		if len(page.Mowers) != len(offsets) || page.Next != nil {
+			t.Errorf("got %v want all %d mowers on one page", ids(page.Mowers), len(offsets))
+		}
+	})
+}
+
+func ids(mowers []*domain.Mower) []string {
+	got := []string{}
+
+	for _, mower := range mowers {
+		got = append(got, mower.ID)
+	}
+
+	return got
+}
@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	pqUniqueViolation = "23505"
+
+	unitColumns = "id, created_at, updated_at, deleted_at, ns, model, store_id"
+)
+
+type PostgresInventoryRepo struct {
+	db    *sql.DB
+	clock domain.Clock
+	ids   domain.IDGenerator
+}
+
+func NewPostgresInventoryRepo(db *sql.DB, opts ...Option) *PostgresInventoryRepo {
+	o := newOptions(opts)
+
+	return &PostgresInventoryRepo{db: db, clock: o.clock, ids: o.ids}
+}
+
+func (r *PostgresInventoryRepo) Add(ctx context.Context, input domain.RegisterUnitDTO) (*domain.InventoryUnit, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+
+	if err != nil {
+		return nil, queryErr(ctx, err)
+	}
+
+	// the model cannot be deleted until the unit is stored, see
+	// PostgresRepo.Delete
+	err = tx.QueryRowContext(ctx,
+		`SELECT 1 FROM mowers WHERE id = $1 AND deleted_at IS NULL FOR SHARE`,
+		input.ModelID,
+	).Scan(new(int))
+
+	if isNotFound(err) {
+		tx.Rollback()
+		return nil, domain.MowerNotFound(input.ModelID)
+	}
+
+	var unit *domain.InventoryUnit
+
+	if err == nil {
+		row := tx.QueryRowContext(ctx,
+			`INSERT INTO store_inventory (id, ns, model, store_id, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $5)
+			RETURNING `+unitColumns,
+			r.ids.NewID(), input.SerialNumber, input.ModelID, input.StoreID, r.now(),
+		)
+
+		unit, err = scanUnit(row)
+	}
+
+	if err != nil {
+		tx.Rollback()
+
+		var pqErr *pq.Error
+
+		if errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation {
+			return nil, domain.ErrSerialNumberTaken
+		}
+
+		return nil, queryErr(ctx, err)
+	}
+
+	return unit, queryErr(ctx, tx.Commit())
+}
+
+func (r *PostgresInventoryRepo) Find(ctx context.Context, id string) (*domain.InventoryUnit, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+unitColumns+` FROM store_inventory WHERE id = $1`, id)
+
+	unit, err := scanUnit(row)
+
+	if isNotFound(err) {
+		return nil, nil
+	}
+
+	return unit, queryErr(ctx, err)
+}
+
+func (r *PostgresInventoryRepo) Retire(ctx context.Context, id string) (*domain.InventoryUnit, error) {
+	row := r.db.QueryRowContext(ctx,
+		`UPDATE store_inventory
+		SET deleted_at = COALESCE(deleted_at, $2),
+			updated_at = CASE WHEN deleted_at IS NULL THEN $2 ELSE updated_at END
+		WHERE id = $1
+		RETURNING `+unitColumns,
+		id, r.now(),
+	)
+
+	unit, err := scanUnit(row)
+
+	if isNotFound(err) {
+		return nil, nil
+	}
+
+	return unit, queryErr(ctx, err)
+}
+
+func (r *PostgresInventoryRepo) Move(ctx context.Context, id string, storeID string) (*domain.InventoryUnit, error) {
+	row := r.db.QueryRowContext(ctx,
+		`UPDATE store_inventory SET store_id = $2, updated_at = $3 WHERE id = $1 RETURNING `+unitColumns,
+		id, storeID, r.now(),
+	)
+
+	unit, err := scanUnit(row)
+
+	if isNotFound(err) {
+		return nil, nil
+	}
+
+	return unit, queryErr(ctx, err)
+}
+
+func (r *PostgresInventoryRepo) FindByStore(ctx context.Context, storeID string) ([]*domain.InventoryUnit, error) {
+	return r.findLive(ctx, `store_id = $1`, storeID)
+}
+
+func (r *PostgresInventoryRepo) FindByModel(ctx context.Context, modelID string) ([]*domain.InventoryUnit, error) {
+	return r.findLive(ctx, `model = $1`, modelID)
+}
+
+func (r *PostgresInventoryRepo) FindStockedModelIDs(ctx context.Context, storeID string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT DISTINCT model FROM store_inventory WHERE store_id = $1 AND deleted_at IS NULL ORDER BY model`,
+		storeID,
+	)
+
+	if isNotFound(err) {
+		return []string{}, nil
+	}
+
+	if err != nil {
+		return nil, queryErr(ctx, err)
+	}
+
+	defer rows.Close()
+
+	modelIDs := []string{}
+
+	for rows.Next() {
+		var modelID string
+
+		if err := rows.Scan(&modelID); err != nil {
+			return nil, queryErr(ctx, err)
+		}
+
+		modelIDs = append(modelIDs, modelID)
+	}
+
+	return modelIDs, queryErr(ctx, rows.Err())
+}
+
+func (r *PostgresInventoryRepo) findLive(ctx context.Context, where string, arg interface{}) ([]*domain.InventoryUnit, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+unitColumns+` FROM store_inventory WHERE `+where+` AND deleted_at IS NULL ORDER BY created_at, id`,
+		arg,
+	)
+
+	if isNotFound(err) {
+		return []*domain.InventoryUnit{}, nil
+	}
+
+	if err != nil {
+		return nil, queryErr(ctx, err)
+	}
+
+	defer rows.Close()
+
+	units := []*domain.InventoryUnit{}
+
+	for rows.Next() {
+		unit, err := scanUnit(rows)
+
+		if err != nil {
+			return nil, queryErr(ctx, err)
+		}
+
+		units = append(units, unit)
+	}
+
+	return units, queryErr(ctx, rows.Err())
+}
+
+func (r *PostgresInventoryRepo) now() time.Time {
+	return time.Time(*domain.Stamp(r.clock))
+}
+
+func scanUnit(row rowScanner) (*domain.InventoryUnit, error) {
+	var (
+		unit                            domain.InventoryUnit
+		createdAt, updatedAt, deletedAt sql.NullTime
+	)
+
+	err := row.Scan(&unit.ID, &createdAt, &updatedAt, &deletedAt, &unit.SerialNumber, &unit.ModelID, &unit.StoreID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	unit.CreatedAt = toTimestamp(createdAt)
+	unit.UpdatedAt = toTimestamp(updatedAt)
+	unit.DeletedAt = toTimestamp(deletedAt)
+
+	return &unit, nil
+}
@@ -0,0 +1,586 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	pqInvalidTextRepresentation = "22P02"
+
+	mowerColumns = "id, created_at, updated_at, deleted_at, version, name, " +
+		"power_source, cutting_width_cm, cutting_height_min_mm, cutting_height_max_mm, " +
+		"grass_bag_liters, weight_kg, self_propelled, recommended_area_m2, description"
+)
+
+type PostgresRepo struct {
+	db    *sql.DB
+	clock domain.Clock
+	ids   domain.IDGenerator
+}
+
+func NewPostgresRepo(db *sql.DB, opts ...Option) *PostgresRepo {
+	o := newOptions(opts)
+
+	return &PostgresRepo{db: db, clock: o.clock, ids: o.ids}
+}
+
+// OpenPostgres connects to the database described by dsn and checks that it
+// is reachable. The pool can be shared by every Postgres repository.
+func OpenPostgres(dsn string) (*sql.DB, error) {
+	db, err := sql.Open("postgres", dsn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func OpenPostgresRepo(dsn string, opts ...Option) (*PostgresRepo, error) {
+	db, err := OpenPostgres(dsn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPostgresRepo(db, opts...), nil
+}
+
+func (r *PostgresRepo) Close() error {
+	return r.db.Close()
+}
+
+func (r *PostgresRepo) now() time.Time {
+	return time.Time(*domain.Stamp(r.clock))
+}
+
+func (r *PostgresRepo) Add(ctx context.Context, input domain.CreateMowerDTO) (*domain.Mower, error) {
+	specs := input.Specs
+
+	row := r.db.QueryRowContext(ctx,
+		`INSERT INTO mowers (
+			id, name, created_at, updated_at,
+			power_source, cutting_width_cm, cutting_height_min_mm, cutting_height_max_mm,
+			grass_bag_liters, weight_kg, self_propelled, recommended_area_m2, description
+		) VALUES ($1, $2, $3, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING `+mowerColumns,
+		r.ids.NewID(), input.Name, r.now(),
+		specs.PowerSource, specs.CuttingWidthCm, specs.CuttingHeightMinMm, specs.CuttingHeightMaxMm,
+		specs.GrassBagLiters, specs.WeightKg, specs.SelfPropelled, specs.RecommendedAreaM2, specs.Description,
+	)
+
+	mower, err := scanMower(row)
+
+	if isNameTaken(err) {
+		return nil, domain.NameTaken()
+	}
+
+	return mower, queryErr(ctx, err)
+}
+
+func (r *PostgresRepo) Patch(ctx context.Context, id string, input domain.UpdateMowerDTO, expectedVersion int64) (*domain.Mower, error) {
+	var specs domain.MowerSpecsPatch
+
+	if input.Specs != nil {
+		specs = *input.Specs
+	}
+
+	row := r.db.QueryRowContext(ctx,
+		`UPDATE mowers
+		SET name = COALESCE(NULLIF($2, ''), name), updated_at = $3, version = version + 1,
+			power_source = COALESCE($5::text, power_source),
+			cutting_width_cm = COALESCE($6::integer, cutting_width_cm),
+			cutting_height_min_mm = COALESCE($7::integer, cutting_height_min_mm),
+			cutting_height_max_mm = COALESCE($8::integer, cutting_height_max_mm),
+			grass_bag_liters = COALESCE($9::integer, grass_bag_liters),
+			weight_kg = COALESCE($10::double precision, weight_kg),
+			self_propelled = COALESCE($11::boolean, self_propelled),
+			recommended_area_m2 = COALESCE($12::integer, recommended_area_m2),
+			description = COALESCE($13::text, description)
+		WHERE id = $1 AND ($4 = 0 OR version = $4)
+		RETURNING `+mowerColumns,
+		id, input.Name, r.now(), expectedVersion,
+		specs.PowerSource, specs.CuttingWidthCm, specs.CuttingHeightMinMm, specs.CuttingHeightMaxMm,
+		specs.GrassBagLiters, specs.WeightKg, specs.SelfPropelled, specs.RecommendedAreaM2, specs.Description,
+	)
+
+	mower, err := scanMower(row)
+
+	if isNameTaken(err) {
+		return nil, domain.NameTaken()
+	}
+
+	if !isNotFound(err) {
+		return mower, queryErr(ctx, err)
+	}
+
+	// Nothing matched: either the mower is unknown or it moved past the
+	// expected version in the meantime.
+	existing, err := r.Find(ctx, id)
+
+	if err != nil || existing == nil {
+		return nil, queryErr(ctx, err)
+	}
+
+	return nil, domain.ErrVersionConflict
+}
+
+func (r *PostgresRepo) Find(ctx context.Context, id string) (*domain.Mower, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+mowerColumns+` FROM mowers WHERE id = $1`, id)
+
+	mower, err := scanMower(row)
+
+	if isNotFound(err) {
+		return nil, nil
+	}
+
+	return mower, queryErr(ctx, err)
+}
+
+func (r *PostgresRepo) FindByName(ctx context.Context, name string) (*domain.Mower, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT `+mowerColumns+` FROM mowers WHERE lower(name) = lower($1) ORDER BY created_at, id LIMIT 1`,
+		name,
+	)
+
+	mower, err := scanMower(row)
+
+	if isNotFound(err) {
+		return nil, nil
+	}
+
+	return mower, queryErr(ctx, err)
+}
+
+func (r *PostgresRepo) Delete(ctx context.Context, id string) (*domain.Mower, error) {
+	tx, err := r.lockUnstocked(ctx, id, false)
+
+	if tx == nil {
+		return nil, err
+	}
+
+	row := tx.QueryRowContext(ctx,
+		`UPDATE mowers
+		SET deleted_at = COALESCE(deleted_at, $2),
+			updated_at = CASE WHEN deleted_at IS NULL THEN $2 ELSE updated_at END,
+			version = CASE WHEN deleted_at IS NULL THEN version + 1 ELSE version END
+		WHERE id = $1
+		RETURNING `+mowerColumns,
+		id, r.now(),
+	)
+
+	mower, err := scanMower(row)
+
+	return commitMower(ctx, tx, mower, err)
+}
+
+func (r *PostgresRepo) Restore(ctx context.Context, id string) (*domain.Mower, error) {
+	row := r.db.QueryRowContext(ctx,
+		`UPDATE mowers
+		SET deleted_at = NULL,
+			updated_at = CASE WHEN deleted_at IS NULL THEN updated_at ELSE $2 END,
+			version = CASE WHEN deleted_at IS NULL THEN version ELSE version + 1 END
+		WHERE id = $1
+		RETURNING `+mowerColumns,
+		id, r.now(),
+	)
+
+	mower, err := scanMower(row)
+
+	if isNotFound(err) {
+		return nil, nil
+	}
+
+	return mower, queryErr(ctx, err)
+}
+
+func (r *PostgresRepo) Purge(ctx context.Context, id string) (*domain.Mower, error) {
+	tx, err := r.lockUnstocked(ctx, id, true)
+
+	if tx == nil {
+		return nil, err
+	}
+
+	row := tx.QueryRowContext(ctx, `DELETE FROM mowers WHERE id = $1 RETURNING `+mowerColumns, id)
+
+	mower, err := scanMower(row)
+
+	return commitMower(ctx, tx, mower, err)
+}
+
+// lockUnstocked starts a transaction holding the row of the mower, which
+// units being registered for it wait for, then refuses to go on while live
+// units point at it, or any unit when retired is set: purging would drop the
+// retired units and free their serial numbers. Checking in a statement of
+// its own sees the units registered before the lock was granted. It returns
+// no transaction when there is no such mower.
+func (r *PostgresRepo) lockUnstocked(ctx context.Context, id string, retired bool) (*sql.Tx, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+
+	if err != nil {
+		return nil, queryErr(ctx, err)
+	}
+
+	err = tx.QueryRowContext(ctx, `SELECT 1 FROM mowers WHERE id = $1 FOR UPDATE`, id).Scan(new(int))
+
+	if isNotFound(err) {
+		tx.Rollback()
+		return nil, nil
+	}
+
+	var stocked bool
+
+	if err == nil {
+		err = tx.QueryRowContext(ctx,
+			`SELECT EXISTS (SELECT 1 FROM store_inventory WHERE model = $1 AND ($2 OR deleted_at IS NULL))`,
+			id, retired,
+		).Scan(&stocked)
+	}
+
+	if err == nil && stocked {
+		err = domain.ErrMowerHasInventory
+	}
+
+	if err != nil {
+		tx.Rollback()
+		return nil, queryErr(ctx, err)
+	}
+
+	return tx, nil
+}
+
+// commitMower ends tx, keeping its changes only when err is nil.
+func commitMower(ctx context.Context, tx *sql.Tx, mower *domain.Mower, err error) (*domain.Mower, error) {
+	if err != nil {
+		tx.Rollback()
+		return nil, queryErr(ctx, err)
+	}
+
+	return mower, queryErr(ctx, tx.Commit())
+}
+
+// mowerFieldColumns maps the query fields to their column.
+var mowerFieldColumns = map[string]string{
+	"name":               "name",
+	"powerSource":        "power_source",
+	"cuttingWidthCm":     "cutting_width_cm",
+	"cuttingHeightMinMm": "cutting_height_min_mm",
+	"cuttingHeightMaxMm": "cutting_height_max_mm",
+	"grassBagLiters":     "grass_bag_liters",
+	"weightKg":           "weight_kg",
+	"recommendedAreaM2":  "recommended_area_m2",
+	"selfPropelled":      "self_propelled",
+	"createdAt":          "created_at",
+}
+
+var sqlOperators = map[domain.Operator]string{
+	domain.OpEq: "=", domain.OpNe: "<>", domain.OpGt: ">", domain.OpGte: ">=", domain.OpLt: "<", domain.OpLte: "<=",
+}
+
+func (r *PostgresRepo) FindAvailableMowers(ctx context.Context, query domain.MowerQuery) ([]*domain.Mower, error) {
+	where, args := mowerQueryWhere(query)
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+mowerColumns+` FROM mowers WHERE `+where+` ORDER BY `+mowerQueryOrder(query.Sort, false),
+		args...,
+	)
+
+	if err != nil {
+		return nil, queryErr(ctx, err)
+	}
+
+	defer rows.Close()
+
+	mowers := []*domain.Mower{}
+
+	for rows.Next() {
+		mower, err := scanMower(rows)
+
+		if err != nil {
+			return nil, queryErr(ctx, err)
+		}
+
+		mowers = append(mowers, mower)
+	}
+
+	return mowers, queryErr(ctx, rows.Err())
+}
+
+// mowerQueryOrder orders by the fields of sort then (created_at, id), the
+// other way round when backwards.
+func mowerQueryOrder(sort []domain.SortField, backwards bool) string {
+	order := []string{}
+
+	for _, s := range sort {
+		column := sortColumn(s.Field)
+
+		if s.Desc != backwards {
+			column += " DESC"
+		}
+
+		order = append(order, column)
+	}
+
+	for _, column := range []string{"created_at", "id"} {
+		if backwards {
+			column += " DESC"
+		}
+
+		order = append(order, column)
+	}
+
+	return strings.Join(order, ", ")
+}
+
+func sortColumn(field string) string {
+	column := mowerFieldColumns[field]
+
+	if column == "name" || column == "power_source" {
+		// Byte order, like the in-memory repository.
+		column += ` COLLATE "C"`
+	}
+
+	return column
+}
+
+// keysetCondition keeps the rows past cursor in the order of sort, as
+// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ... down to (created_at, id), with
+// each comparison turned around for descending fields and backward cursors.
+func keysetCondition(cursor *domain.Cursor, sort []domain.SortField, args []interface{}) (string, []interface{}) {
+	type key struct {
+		column, cast string
+		desc         bool
+		value        interface{}
+	}
+
+	keys := []key{}
+
+	for i, s := range sort {
+		value := cursor.Keys[i].Value
+		keys = append(keys, key{sortColumn(s.Field), sqlType(value), s.Desc, value})
+	}
+
+	keys = append(keys,
+		key{"created_at", "timestamptz", false, cursor.CreatedAt},
+		key{"id", "uuid", false, cursor.ID},
+	)
+
+	placeholders := make([]string, len(keys))
+
+	for i, k := range keys {
+		args = append(args, k.value)
+		placeholders[i] = fmt.Sprintf("$%d::%s", len(args), k.cast)
+	}
+
+	alternatives := []string{}
+
+	for i, k := range keys {
+		terms := []string{}
+
+		for j := 0; j < i; j++ {
+			terms = append(terms, keys[j].column+" = "+placeholders[j])
+		}
+
+		op := ">"
+
+		if k.desc != cursor.Before {
+			op = "<"
+		}
+
+		terms = append(terms, k.column+" "+op+" "+placeholders[i])
+		alternatives = append(alternatives, "("+strings.Join(terms, " AND ")+")")
+	}
+
+	return "(" + strings.Join(alternatives, " OR ") + ")", args
+}
+
+func mowerQueryWhere(query domain.MowerQuery) (string, []interface{}) {
+	conditions := []string{}
+	args := []interface{}{}
+
+	switch query.Availability {
+	case domain.OnlyAvailable:
+		conditions = append(conditions, "deleted_at IS NULL")
+	case domain.OnlyDeleted:
+		conditions = append(conditions, "deleted_at IS NOT NULL")
+	}
+
+	if query.IDs != nil {
+		args = append(args, pq.Array(query.IDs))
+		conditions = append(conditions, fmt.Sprintf("id = ANY($%d::uuid[])", len(args)))
+	}
+
+	for _, filter := range query.Filters {
+		column := mowerFieldColumns[filter.Field]
+		args = append(args, filter.Value)
+		condition := fmt.Sprintf("%s %s $%d::%s", column, sqlOperators[filter.Op], len(args), sqlType(filter.Value))
+
+		if filter.SkipsUnknown() {
+			condition = fmt.Sprintf("(%s <> 0 AND %s)", column, condition)
+		}
+
+		conditions = append(conditions, condition)
+	}
+
+	if len(conditions) == 0 {
+		return "true", args
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+func sqlType(value interface{}) string {
+	switch value.(type) {
+	case float64:
+		return "double precision"
+	case bool:
+		return "boolean"
+	case time.Time:
+		return "timestamptz"
+	}
+
+	return "text"
+}
+
+// FindMowerPage uses keyset queries on the sort fields then (created_at,
+// id): it never skips rows, however deep the page.
+func (r *PostgresRepo) FindMowerPage(ctx context.Context, query domain.PageQuery) (*domain.MowerPage, error) {
+	where, args := mowerQueryWhere(query.MowerQuery)
+	countWhere, countArgs := where, args
+	backwards := query.Cursor != nil && query.Cursor.Before
+
+	if query.Cursor != nil {
+		var condition string
+
+		condition, args = keysetCondition(query.Cursor, query.Sort, append([]interface{}{}, args...))
+		where += " AND " + condition
+	}
+
+	args = append(args, query.Limit+1)
+
+	rows, err := r.db.QueryContext(ctx,
+		fmt.Sprintf(`SELECT %s FROM mowers WHERE %s ORDER BY %s LIMIT $%d`, mowerColumns, where, mowerQueryOrder(query.Sort, backwards), len(args)),
+		args...,
+	)
+
+	if isNotFound(err) {
+		return nil, domain.InvalidQuery("cursor is malformed")
+	}
+
+	if err != nil {
+		return nil, queryErr(ctx, err)
+	}
+
+	defer rows.Close()
+
+	fetched := []*domain.Mower{}
+
+	for rows.Next() {
+		mower, err := scanMower(rows)
+
+		if err != nil {
+			return nil, queryErr(ctx, err)
+		}
+
+		fetched = append(fetched, mower)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, queryErr(ctx, err)
+	}
+
+	page := domain.NewMowerPage(fetched, query)
+
+	if query.CountTotal {
+		var total int
+
+		err := r.db.QueryRowContext(ctx, `SELECT count(*) FROM mowers WHERE `+countWhere, countArgs...).Scan(&total)
+
+		if err != nil {
+			return nil, queryErr(ctx, err)
+		}
+
+		page.Total = &total
+	}
+
+	return page, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanMower(row rowScanner) (*domain.Mower, error) {
+	var (
+		mower                           domain.Mower
+		createdAt, updatedAt, deletedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&mower.ID, &createdAt, &updatedAt, &deletedAt, &mower.Version, &mower.Name,
+		&mower.Specs.PowerSource, &mower.Specs.CuttingWidthCm, &mower.Specs.CuttingHeightMinMm, &mower.Specs.CuttingHeightMaxMm,
+		&mower.Specs.GrassBagLiters, &mower.Specs.WeightKg, &mower.Specs.SelfPropelled, &mower.Specs.RecommendedAreaM2,
+		&mower.Specs.Description,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	mower.CreatedAt = toTimestamp(createdAt)
+	mower.UpdatedAt = toTimestamp(updatedAt)
+	mower.DeletedAt = toTimestamp(deletedAt)
+
+	return &mower, nil
+}
+
+func toTimestamp(t sql.NullTime) *domain.Timestamp {
+	if !t.Valid {
+		return nil
+	}
+
+	return domain.NewTimestamp(t.Time)
+}
+
+// isNotFound reports whether err means the requested row does not exist,
+// either because no row matched or because the id is not a valid uuid.
+func isNotFound(err error) bool {
+	if errors.Is(err, sql.ErrNoRows) {
+		return true
+	}
+
+	var pqErr *pq.Error
+
+	return errors.As(err, &pqErr) && pqErr.Code == pqInvalidTextRepresentation
+}
+
+// isNameTaken reports whether err is the unique index on the names of the
+// mowers turning down a write.
+func isNameTaken(err error) bool {
+	var pqErr *pq.Error
+
+	return errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation && pqErr.Constraint == "mowers_name_key"
+}
+
+// queryErr returns the error of ctx over err when ctx ended the query, which
+// Postgres reports as a canceled statement.
+func queryErr(ctx context.Context, err error) error {
+	if err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return err
+}
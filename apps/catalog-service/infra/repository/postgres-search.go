@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"strings"
+)
+
+// PostgresSearchIndex is a domain.SearchIndex over the generated
+// search_document column of the mowers table, which Postgres keeps in sync
+// by itself: Index and Remove do nothing. Words match as prefixes of indexed
+// terms, typos are not tolerated.
+type PostgresSearchIndex struct {
+	db *sql.DB
+}
+
+func NewPostgresSearchIndex(db *sql.DB) *PostgresSearchIndex {
+	return &PostgresSearchIndex{db: db}
+}
+
+func (s *PostgresSearchIndex) Index(ctx context.Context, mower *domain.Mower) error {
+	return nil
+}
+
+func (s *PostgresSearchIndex) Remove(ctx context.Context, id string, version int64) error {
+	return nil
+}
+
+func (s *PostgresSearchIndex) Search(ctx context.Context, text string, limit int) ([]domain.SearchHit, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+mowerColumns+`, ts_rank(search_document, query) AS score
+		FROM mowers, to_tsquery('simple', $1) query
+		WHERE deleted_at IS NULL AND search_document @@ query
+		ORDER BY score DESC, name COLLATE "C", id
+		LIMIT $2`,
+		prefixQuery(text, ""), limit,
+	)
+
+	if err != nil {
+		return nil, queryErr(ctx, err)
+	}
+
+	defer rows.Close()
+
+	hits := []domain.SearchHit{}
+
+	for rows.Next() {
+		var hit domain.SearchHit
+
+		hit.Mower, err = scanMower(scoredRow{rows, &hit.Score})
+
+		if err != nil {
+			return nil, queryErr(ctx, err)
+		}
+
+		hits = append(hits, hit)
+	}
+
+	return hits, queryErr(ctx, rows.Err())
+}
+
+// Suggest only looks at names, which are weighted A in search_document.
+func (s *PostgresSearchIndex) Suggest(ctx context.Context, prefix string, limit int) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT name
+		FROM mowers, to_tsquery('simple', $1) query
+		WHERE deleted_at IS NULL AND search_document @@ query
+		GROUP BY name
+		ORDER BY max(ts_rank(search_document, query)) DESC, name COLLATE "C"
+		LIMIT $2`,
+		prefixQuery(prefix, "A"), limit,
+	)
+
+	if err != nil {
+		return nil, queryErr(ctx, err)
+	}
+
+	defer rows.Close()
+
+	names := []string{}
+
+	for rows.Next() {
+		var name string
+
+		if err := rows.Scan(&name); err != nil {
+			return nil, queryErr(ctx, err)
+		}
+
+		names = append(names, name)
+	}
+
+	return names, queryErr(ctx, rows.Err())
+}
+
+// prefixQuery turns text into a tsquery matching every word as a prefix, in
+// the given weights. Words only hold letters and digits, so they need no
+// escaping.
+func prefixQuery(text, weights string) string {
+	terms := []string{}
+
+	for _, word := range domain.SearchWords(text) {
+		terms = append(terms, word[0]+":*"+weights)
+	}
+
+	return strings.Join(terms, " & ")
+}
+
+// scoredRow scans the columns of a mower followed by a score.
+type scoredRow struct {
+	rows  rowScanner
+	score *float64
+}
+
+func (r scoredRow) Scan(dest ...interface{}) error {
+	return r.rows.Scan(append(dest, r.score)...)
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	lmTesting "jrobic/lawn-mower/catalog-service"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"reflect"
+	"testing"
+)
+
+func TestPostgresSearchIndex(t *testing.T) {
+	repo := newTestPostgresRepo(t)
+	index := NewPostgresSearchIndex(repo.db)
+
+	for _, input := range []domain.CreateMowerDTO{
+		{Name: "M-90", Specs: domain.MowerSpecs{PowerSource: domain.PowerSourcePetrol}},
+		{Name: "M-150", Specs: domain.MowerSpecs{PowerSource: domain.PowerSourceBattery, Description: "Quiet"}},
+		{Name: "M-480", Specs: domain.MowerSpecs{PowerSource: domain.PowerSourceBattery}},
+	} {
+		_, err := repo.Add(context.Background(), input)
+		lmTesting.AssertNoError(t, err)
+	}
+
+	t.Run("search every word as a prefix", func(t *testing.T) {
+		hits, err := index.Search(context.Background(), "batt qui", 10)
+		lmTesting.AssertNoError(t, err)
+
+		if len(hits) != 1 || hits[0].Mower.Name != "M-150" {
+			t.Errorf("got %v want M-150", hits)
+		}
+	})
+
+	t.Run("suggest partial model names", func(t *testing.T) {
+		names, err := index.Suggest(context.Background(), "M-4", 10)
+		lmTesting.AssertNoError(t, err)
+
+		if want := []string{"M-480"}; !reflect.DeepEqual(names, want) {
+			t.Errorf("got %v want %v", names, want)
+		}
+	})
+}
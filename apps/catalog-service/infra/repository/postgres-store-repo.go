@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"time"
+)
+
+const storeColumns = "id, created_at, updated_at, deleted_at, name"
+
+type PostgresStoreRepo struct {
+	db    *sql.DB
+	clock domain.Clock
+	ids   domain.IDGenerator
+}
+
+func NewPostgresStoreRepo(db *sql.DB, opts ...Option) *PostgresStoreRepo {
+	o := newOptions(opts)
+
+	return &PostgresStoreRepo{db: db, clock: o.clock, ids: o.ids}
+}
+
+func (r *PostgresStoreRepo) Add(ctx context.Context, input domain.CreateStoreDTO) (*domain.Store, error) {
+	row := r.db.QueryRowContext(ctx,
+		`INSERT INTO stores (id, name, created_at, updated_at) VALUES ($1, $2, $3, $3) RETURNING `+storeColumns,
+		r.ids.NewID(), input.Name, r.now(),
+	)
+
+	store, err := scanStore(row)
+
+	return store, queryErr(ctx, err)
+}
+
+func (r *PostgresStoreRepo) Patch(ctx context.Context, id string, input domain.UpdateStoreDTO) (*domain.Store, error) {
+	row := r.db.QueryRowContext(ctx,
+		`UPDATE stores
+		SET name = COALESCE(NULLIF($2, ''), name), updated_at = $3
+		WHERE id = $1
+		RETURNING `+storeColumns,
+		id, input.Name, r.now(),
+	)
+
+	store, err := scanStore(row)
+
+	if isNotFound(err) {
+		return nil, nil
+	}
+
+	return store, queryErr(ctx, err)
+}
+
+func (r *PostgresStoreRepo) Find(ctx context.Context, id string) (*domain.Store, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+storeColumns+` FROM stores WHERE id = $1`, id)
+
+	store, err := scanStore(row)
+
+	if isNotFound(err) {
+		return nil, nil
+	}
+
+	return store, queryErr(ctx, err)
+}
+
+func (r *PostgresStoreRepo) now() time.Time {
+	return time.Time(*domain.Stamp(r.clock))
+}
+
+func scanStore(row rowScanner) (*domain.Store, error) {
+	var (
+		store                           domain.Store
+		createdAt, updatedAt, deletedAt sql.NullTime
+	)
+
+	err := row.Scan(&store.ID, &createdAt, &updatedAt, &deletedAt, &store.Name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	store.CreatedAt = toTimestamp(createdAt)
+	store.UpdatedAt = toTimestamp(updatedAt)
+	store.DeletedAt = toTimestamp(deletedAt)
+
+	return &store, nil
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	lmTesting "jrobic/lawn-mower/catalog-service"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestInMemoryRepoQuery(t *testing.T) {
+	clock := lmTesting.NewFakeClock(time.Time{})
+
+	assertQuery(t, NewInMemoryRepo([]*domain.Mower{}, WithClock(clock)), clock)
+}
+
+func TestPostgresRepoQuery(t *testing.T) {
+	clock := lmTesting.NewFakeClock(time.Time{})
+
+	assertQuery(t, newTestPostgresRepo(t, WithClock(clock)), clock)
+}
+
+// assertQuery checks the MowerQuery rules every CatalogRepository shares:
+// filters, multi-field sorts, availability and unknown specs.
+func assertQuery(t *testing.T, repo domain.CatalogRepository, clock *lmTesting.FakeClock) {
+	t.Helper()
+
+	start := time.Date(2022, time.July, 14, 10, 0, 0, 0, time.UTC)
+	inputs := []domain.CreateMowerDTO{
+		{Name: "Q-A", Specs: domain.MowerSpecs{PowerSource: domain.PowerSourceBattery, CuttingWidthCm: 46, WeightKg: 20}},
+		{Name: "Q-B", Specs: domain.MowerSpecs{PowerSource: domain.PowerSourceBattery, CuttingWidthCm: 38, WeightKg: 15}},
+		{Name: "Q-C", Specs: domain.MowerSpecs{PowerSource: domain.PowerSourcePetrol, CuttingWidthCm: 53, WeightKg: 35, SelfPropelled: true}},
+		{Name: "Q-D", Specs: domain.MowerSpecs{PowerSource: domain.PowerSourceBattery}},
+		{Name: "Q-E", Specs: domain.MowerSpecs{PowerSource: domain.PowerSourceBattery, CuttingWidthCm: 51, WeightKg: 25}},
+	}
+
+	ids := map[string]string{}
+
+	for i, input := range inputs {
+		clock.Set(start.Add(time.Duration(i) * time.Minute))
+
+		mower, err := repo.Add(context.Background(), input)
+		lmTesting.AssertNoError(t, err)
+
+		ids[input.Name] = mower.ID
+	}
+
+	_, err := repo.Delete(context.Background(), ids["Q-E"])
+	lmTesting.AssertNoError(t, err)
+
+	filter := func(field string, op domain.Operator, raw string) domain.MowerFilter {
+		f, err := domain.NewMowerFilter(field, op, raw)
+		lmTesting.AssertNoError(t, err)
+		return f
+	}
+
+	cases := []struct {
+		name  string
+		query domain.MowerQuery
+		want  []string
+	}{
+		{
+			"battery mowers wider than 40cm, lightest first",
+			domain.MowerQuery{
+				Filters: []domain.MowerFilter{filter("powerSource", domain.OpEq, "battery"), filter("cuttingWidthCm", domain.OpGt, "40")},
+				Sort:    []domain.SortField{{Field: "weightKg"}},
+			},
+			[]string{"Q-A"},
+		},
+		{
+			"deleted mowers included",
+			domain.MowerQuery{
+				Filters:      []domain.MowerFilter{filter("cuttingWidthCm", domain.OpGt, "40")},
+				Sort:         []domain.SortField{{Field: "weightKg", Desc: true}},
+				Availability: domain.AnyAvailability,
+			},
+			[]string{"Q-C", "Q-E", "Q-A"},
+		},
+		{
+			"only deleted mowers",
+			domain.MowerQuery{Availability: domain.OnlyDeleted},
+			[]string{"Q-E"},
+		},
+		{
+			"unknown specs never match",
+			domain.MowerQuery{Filters: []domain.MowerFilter{filter("cuttingWidthCm", domain.OpLt, "40")}},
+			[]string{"Q-B"},
+		},
+		{
+			"sort on several fields",
+			domain.MowerQuery{Sort: []domain.SortField{{Field: "powerSource", Desc: true}, {Field: "cuttingWidthCm", Desc: true}}},
+			[]string{"Q-C", "Q-A", "Q-B", "Q-D"},
+		},
+		{
+			"created date and booleans",
+			domain.MowerQuery{Filters: []domain.MowerFilter{
+				filter("createdAt", domain.OpGte, start.Add(2*time.Minute).Format(time.RFC3339)),
+				filter("selfPropelled", domain.OpEq, "false"),
+			}},
+			[]string{"Q-D"},
+		},
+		{
+			"restricted to ids",
+			domain.MowerQuery{IDs: []string{ids["Q-C"], ids["Q-B"], ids["Q-E"]}},
+			[]string{"Q-B", "Q-C"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mowers, err := repo.FindAvailableMowers(context.Background(), c.query)
+			lmTesting.AssertNoError(t, err)
+
+			got := []string{}
+
+			for _, mower := range mowers {
+				got = append(got, mower.Name)
+			}
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("got %v want %v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,378 @@
+package search
+
+import (
+	"context"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Weights of the mower fields in the ranking: a word found in the name is
+// worth more than one found in the description.
+const (
+	nameWeight        = 3
+	powerSourceWeight = 2
+	descriptionWeight = 1
+)
+
+// InvertedIndex is an in-process domain.SearchIndex. Every word is looked up
+// exactly, as a prefix of indexed terms, then with a typo or two for longer
+// words.
+type InvertedIndex struct {
+	lock   sync.RWMutex
+	all    *termIndex
+	names  *termIndex
+	mowers map[string]*domain.Mower
+	// versions are the last version of every mower indexed or removed,
+	// removals staying as tombstones so that older changes arriving late
+	// cannot bring a mower back.
+	versions map[string]indexedVersion
+}
+
+type indexedVersion struct {
+	version int64
+	removed bool
+}
+
+func NewInvertedIndex() *InvertedIndex {
+	return &InvertedIndex{
+		all:      newTermIndex(),
+		names:    newTermIndex(),
+		mowers:   map[string]*domain.Mower{},
+		versions: map[string]indexedVersion{},
+	}
+}
+
+// BuildInvertedIndex indexes the live mowers of repo, to start a service
+// from a catalog that already has mowers.
+func BuildInvertedIndex(ctx context.Context, repo domain.CatalogRepository) (*InvertedIndex, error) {
+	index := NewInvertedIndex()
+
+	mowers, err := repo.FindAvailableMowers(ctx, domain.MowerQuery{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mower := range mowers {
+		if err := index.Index(ctx, mower); err != nil {
+			return nil, err
+		}
+	}
+
+	return index, nil
+}
+
+// Index ignores versions older than the one indexed, and versions no newer
+// than the one removed.
+func (idx *InvertedIndex) Index(ctx context.Context, mower *domain.Mower) error {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	if last, ok := idx.versions[mower.ID]; ok && (mower.Version < last.version || (last.removed && mower.Version == last.version)) {
+		return nil
+	}
+
+	idx.versions[mower.ID] = indexedVersion{version: mower.Version}
+	idx.remove(mower.ID)
+
+	idx.mowers[mower.ID] = copyMower(mower)
+
+	idx.names.add(mower.ID, mower.Name, nameWeight)
+	idx.all.add(mower.ID, mower.Name, nameWeight)
+	idx.all.add(mower.ID, string(mower.Specs.PowerSource), powerSourceWeight)
+	idx.all.add(mower.ID, mower.Specs.Description, descriptionWeight)
+
+	return nil
+}
+
+// Remove ignores versions older than the one indexed or removed.
+func (idx *InvertedIndex) Remove(ctx context.Context, id string, version int64) error {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	if last, ok := idx.versions[id]; ok && version < last.version {
+		return nil
+	}
+
+	idx.versions[id] = indexedVersion{version: version, removed: true}
+	idx.remove(id)
+
+	return nil
+}
+
+func (idx *InvertedIndex) remove(id string) {
+	idx.names.remove(id)
+	idx.all.remove(id)
+	delete(idx.mowers, id)
+}
+
+func (idx *InvertedIndex) Search(ctx context.Context, text string, limit int) ([]domain.SearchHit, error) {
+	idx.lock.RLock()
+	defer idx.lock.RUnlock()
+
+	hits := idx.rank(idx.all, text)
+
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	for i := range hits {
+		hits[i].Mower = copyMower(hits[i].Mower)
+	}
+
+	return hits, nil
+}
+
+func (idx *InvertedIndex) Suggest(ctx context.Context, prefix string, limit int) ([]string, error) {
+	idx.lock.RLock()
+	defer idx.lock.RUnlock()
+
+	names := []string{}
+	seen := map[string]bool{}
+
+	for _, hit := range idx.rank(idx.names, prefix) {
+		if len(names) == limit {
+			break
+		}
+
+		if !seen[hit.Mower.Name] {
+			seen[hit.Mower.Name] = true
+			names = append(names, hit.Mower.Name)
+		}
+	}
+
+	return names, nil
+}
+
+// rank scores the mowers matching every word of text: the sum, over the
+// words, of their best match.
+func (idx *InvertedIndex) rank(terms *termIndex, text string) []domain.SearchHit {
+	scores := map[string]float64{}
+
+	for i, word := range domain.SearchWords(text) {
+		matches := terms.match(word[0])
+
+		for id := range scores {
+			if _, ok := matches[id]; !ok {
+				delete(scores, id)
+			}
+		}
+
+		for id, score := range matches {
+			if _, ok := scores[id]; ok || i == 0 {
+				scores[id] += score
+			}
+		}
+	}
+
+	hits := []domain.SearchHit{}
+
+	for id, score := range scores {
+		hits = append(hits, domain.SearchHit{Mower: idx.mowers[id], Score: score})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		a, b := hits[i], hits[j]
+
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+
+		if a.Mower.Name != b.Mower.Name {
+			return a.Mower.Name < b.Mower.Name
+		}
+
+		return a.Mower.ID < b.Mower.ID
+	})
+
+	return hits
+}
+
+// termIndex maps every term to the weight it has in each mower.
+type termIndex struct {
+	postings map[string]map[string]float64
+	// terms are the keys of postings in order, for prefix lookups.
+	terms []string
+	// termsOf lists the terms of each mower, for removals.
+	termsOf map[string][]string
+}
+
+func newTermIndex() *termIndex {
+	return &termIndex{postings: map[string]map[string]float64{}, termsOf: map[string][]string{}}
+}
+
+func (ti *termIndex) add(id, text string, weight float64) {
+	for _, word := range domain.SearchWords(text) {
+		for _, term := range word {
+			postings, ok := ti.postings[term]
+
+			if !ok {
+				postings = map[string]float64{}
+				ti.postings[term] = postings
+
+				i := sort.SearchStrings(ti.terms, term)
+				ti.terms = append(ti.terms, "")
+				copy(ti.terms[i+1:], ti.terms[i:])
+				ti.terms[i] = term
+			}
+
+			if weight > postings[id] {
+				postings[id] = weight
+			}
+
+			ti.termsOf[id] = append(ti.termsOf[id], term)
+		}
+	}
+}
+
+func (ti *termIndex) remove(id string) {
+	for _, term := range ti.termsOf[id] {
+		postings, ok := ti.postings[term]
+
+		if !ok {
+			continue
+		}
+
+		delete(postings, id)
+
+		if len(postings) == 0 {
+			delete(ti.postings, term)
+
+			i := sort.SearchStrings(ti.terms, term)
+			ti.terms = append(ti.terms[:i], ti.terms[i+1:]...)
+		}
+	}
+
+	delete(ti.termsOf, id)
+}
+
+// match scores the mowers having a term equal to word, starting with word,
+// or, for words long enough, a term a few typos away from it. Exact matches
+// score the full weight of the term, prefixes more as word covers more of
+// the term, typos the least.
+func (ti *termIndex) match(word string) map[string]float64 {
+	scores := map[string]float64{}
+
+	keep := func(term string, factor float64) {
+		for id, weight := range ti.postings[term] {
+			if score := weight * factor; score > scores[id] {
+				scores[id] = score
+			}
+		}
+	}
+
+	keep(word, 1)
+
+	for i := sort.SearchStrings(ti.terms, word); i < len(ti.terms) && strings.HasPrefix(ti.terms[i], word); i++ {
+		if term := ti.terms[i]; term != word {
+			keep(term, 0.5+0.4*float64(len(word))/float64(len(term)))
+		}
+	}
+
+	if maxEdits := allowedTypos(word); maxEdits > 0 {
+		for _, term := range ti.terms {
+			if d := distance(word, term, maxEdits); d > 0 && d <= maxEdits {
+				keep(term, 0.4/float64(d))
+			}
+		}
+	}
+
+	return scores
+}
+
+// allowedTypos is the number of edits a word may be away from a term: none
+// for short words, which would match almost anything, nor for words with
+// digits, such as model numbers.
+func allowedTypos(word string) int {
+	if strings.IndexFunc(word, unicode.IsDigit) >= 0 {
+		return 0
+	}
+
+	switch n := len([]rune(word)); {
+	case n >= 8:
+		return 2
+	case n >= 4:
+		return 1
+	}
+
+	return 0
+}
+
+// distance is the optimal string alignment distance between a and b: the
+// insertions, deletions, substitutions and swaps of neighbour letters to go
+// from one to the other. It gives up with max+1 when the lengths alone are
+// further apart than max.
+func distance(a, b string, max int) int {
+	ra, rb := []rune(a), []rune(b)
+
+	if diff := len(ra) - len(rb); diff > max || -diff > max {
+		return max + 1
+	}
+
+	rows := make([][]int, len(ra)+1)
+
+	for i := range rows {
+		rows[i] = make([]int, len(rb)+1)
+		rows[i][0] = i
+	}
+
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			d := minInt(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d = minInt(d, rows[i-2][j-2]+1)
+			}
+
+			rows[i][j] = d
+		}
+	}
+
+	return rows[len(ra)][len(rb)]
+}
+
+func minInt(values ...int) int {
+	min := values[0]
+
+	for _, v := range values[1:] {
+		if v < min {
+			min = v
+		}
+	}
+
+	return min
+}
+
+// copyMower returns a copy of mower sharing nothing with it, so that neither
+// the mowers indexed nor the hits handed out alias a mower of the caller.
+func copyMower(mower *domain.Mower) *domain.Mower {
+	copied := *mower
+	copied.CreatedAt = copyTimestamp(mower.CreatedAt)
+	copied.UpdatedAt = copyTimestamp(mower.UpdatedAt)
+	copied.DeletedAt = copyTimestamp(mower.DeletedAt)
+
+	return &copied
+}
+
+func copyTimestamp(t *domain.Timestamp) *domain.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	copied := *t
+
+	return &copied
+}
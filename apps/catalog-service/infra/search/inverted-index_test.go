@@ -0,0 +1,185 @@
+package search
+
+import (
+	"context"
+	lmTesting "jrobic/lawn-mower/catalog-service"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestInvertedIndex(t *testing.T) {
+	newIndex := func(t *testing.T) *InvertedIndex {
+		index := NewInvertedIndex()
+
+		for _, mower := range []*domain.Mower{
+			{ID: "1", Name: "M-90", Specs: domain.MowerSpecs{PowerSource: domain.PowerSourcePetrol}},
+			{ID: "2", Name: "M-150", Specs: domain.MowerSpecs{PowerSource: domain.PowerSourceBattery, Description: "Quiet mower for M-480 owners"}},
+			{ID: "3", Name: "M-480", Specs: domain.MowerSpecs{PowerSource: domain.PowerSourceBattery}},
+			{ID: "4", Name: "M-490 Robot", Specs: domain.MowerSpecs{PowerSource: domain.PowerSourceRobotic}},
+		} {
+			lmTesting.AssertNoError(t, index.Index(context.Background(), mower))
+		}
+
+		return index
+	}
+
+	assertNames := func(t *testing.T, hits []domain.SearchHit, want ...string) {
+		t.Helper()
+
+		got, want := []string{}, append([]string{}, want...)
+
+		for _, hit := range hits {
+			got = append(got, hit.Mower.Name)
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v want %v", got, want)
+		}
+	}
+
+	t.Run("search partial model names", func(t *testing.T) {
+		index := newIndex(t)
+
+		for _, text := range []string{"M-4", "m4", "M 4"} {
+			hits, err := index.Search(context.Background(), text, 10)
+			lmTesting.AssertNoError(t, err)
+
+			assertNames(t, hits, "M-480", "M-490 Robot", "M-150")
+		}
+	})
+
+	t.Run("rank exact matches first", func(t *testing.T) {
+		hits, err := newIndex(t).Search(context.Background(), "m-480", 10)
+		lmTesting.AssertNoError(t, err)
+
+		assertNames(t, hits, "M-480", "M-150")
+
+		if hits[0].Score <= hits[1].Score {
+			t.Errorf("expected the name to score more than the description, got %v", hits)
+		}
+	})
+
+	t.Run("match every word", func(t *testing.T) {
+		hits, err := newIndex(t).Search(context.Background(), "battery quiet", 10)
+		lmTesting.AssertNoError(t, err)
+
+		assertNames(t, hits, "M-150")
+	})
+
+	t.Run("tolerate typos in longer words", func(t *testing.T) {
+		hits, err := newIndex(t).Search(context.Background(), "batery", 10)
+		lmTesting.AssertNoError(t, err)
+
+		assertNames(t, hits, "M-150", "M-480")
+
+		hits, err = newIndex(t).Search(context.Background(), "rbt", 10)
+		lmTesting.AssertNoError(t, err)
+
+		assertNames(t, hits)
+	})
+
+	t.Run("limit hits", func(t *testing.T) {
+		hits, err := newIndex(t).Search(context.Background(), "m", 2)
+		lmTesting.AssertNoError(t, err)
+
+		if len(hits) != 2 {
+			t.Errorf("got %d hits want 2", len(hits))
+		}
+	})
+
+	t.Run("suggest names only", func(t *testing.T) {
+		names, err := newIndex(t).Suggest(context.Background(), "M-4", 10)
+		lmTesting.AssertNoError(t, err)
+
+		if want := []string{"M-480", "M-490 Robot"}; !reflect.DeepEqual(names, want) {
+			t.Errorf("got %v want %v", names, want)
+		}
+	})
+
+	t.Run("reindex and remove mowers", func(t *testing.T) {
+		index := newIndex(t)
+
+		lmTesting.AssertNoError(t, index.Index(context.Background(), &domain.Mower{ID: "3", Name: "M-300"}))
+		lmTesting.AssertNoError(t, index.Remove(context.Background(), "4", 0))
+
+		hits, err := index.Search(context.Background(), "M-4", 10)
+		lmTesting.AssertNoError(t, err)
+
+		assertNames(t, hits, "M-150")
+
+		hits, err = index.Search(context.Background(), "m300", 10)
+		lmTesting.AssertNoError(t, err)
+
+		assertNames(t, hits, "M-300")
+	})
+
+	t.Run("ignore changes older than the indexed version", func(t *testing.T) {
+		index := NewInvertedIndex()
+		ctx := context.Background()
+
+		// patched to version 2 then deleted at version 3, applied in reverse
+		lmTesting.AssertNoError(t, index.Remove(ctx, "1", 3))
+		lmTesting.AssertNoError(t, index.Index(ctx, &domain.Mower{ID: "1", Name: "M-90", Version: 2}))
+
+		hits, err := index.Search(ctx, "M-90", 10)
+		lmTesting.AssertNoError(t, err)
+
+		assertNames(t, hits)
+
+		// restored at version 4, then a rename at version 5 overtaken by
+		// the removal of version 3 arriving late
+		lmTesting.AssertNoError(t, index.Index(ctx, &domain.Mower{ID: "1", Name: "M-91", Version: 5}))
+		lmTesting.AssertNoError(t, index.Index(ctx, &domain.Mower{ID: "1", Name: "M-90", Version: 4}))
+		lmTesting.AssertNoError(t, index.Remove(ctx, "1", 3))
+
+		hits, err = index.Search(ctx, "M-9", 10)
+		lmTesting.AssertNoError(t, err)
+
+		assertNames(t, hits, "M-91")
+	})
+
+	t.Run("hand out copies", func(t *testing.T) {
+		index := NewInvertedIndex()
+		ctx := context.Background()
+
+		mower := &domain.Mower{ID: "1", Name: "M-90", CreatedAt: domain.NewTimestamp(time.Now())}
+		lmTesting.AssertNoError(t, index.Index(ctx, mower))
+		mower.Name = "changed by the writer"
+		*mower.CreatedAt = domain.Timestamp{}
+
+		hits, err := index.Search(ctx, "M-90", 10)
+		lmTesting.AssertNoError(t, err)
+		hits[0].Mower.Name = "changed by a reader"
+		*hits[0].Mower.CreatedAt = domain.Timestamp{}
+
+		hits, err = index.Search(ctx, "M-90", 10)
+		lmTesting.AssertNoError(t, err)
+		assertNames(t, hits, "M-90")
+
+		if time.Time(*hits[0].Mower.CreatedAt).IsZero() {
+			t.Errorf("got %v want the creation time indexed", hits[0].Mower)
+		}
+	})
+}
+
+func TestDistance(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"battery", "battery", 0},
+		{"batery", "battery", 1},
+		{"baterry", "battery", 2},
+		{"battrey", "battery", 1},
+		{"petrol", "battery", 6},
+		{"bat", "battery", 3},
+	}
+
+	for _, c := range cases {
+		if got := distance(c.a, c.b, 2); got != c.want {
+			t.Errorf("distance(%q, %q) = %d want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
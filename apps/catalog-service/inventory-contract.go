@@ -0,0 +1,99 @@
+package lmTesting
+
+import (
+	"context"
+	"errors"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"testing"
+)
+
+// InventoryRepositories are the repositories of one backend the inventory
+// contract runs against, linked so that units point at mowers and stores of
+// the other two.
+type InventoryRepositories struct {
+	Catalog   domain.CatalogRepository
+	Stores    domain.StoreRepository
+	Inventory domain.InventoryRepository
+}
+
+// InventoryRepositoryFactory returns empty repositories. It is called once
+// per case of the contract.
+type InventoryRepositoryFactory func(t *testing.T) InventoryRepositories
+
+// RunInventoryRepositoryContract checks that the repositories made by factory
+// keep units and their models consistent the way every backend must: no
+// model with units is removed, and retired serial numbers stay reserved.
+func RunInventoryRepositoryContract(t *testing.T, factory InventoryRepositoryFactory) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	// newUnit registers a unit of a new model at a new store.
+	newUnit := func(t *testing.T, repos InventoryRepositories, serial string) *domain.InventoryUnit {
+		t.Helper()
+
+		mower, err := repos.Catalog.Add(ctx, domain.CreateMowerDTO{Name: "M-" + serial})
+		AssertNoError(t, err)
+
+		store, err := repos.Stores.Add(ctx, domain.CreateStoreDTO{Name: "Store " + serial})
+		AssertNoError(t, err)
+
+		unit, err := repos.Inventory.Add(ctx, domain.RegisterUnitDTO{SerialNumber: serial, ModelID: mower.ID, StoreID: store.ID})
+		AssertNoError(t, err)
+
+		return unit
+	}
+
+	assertHasInventory := func(t *testing.T, call string, err error) {
+		t.Helper()
+
+		if !errors.Is(err, domain.ErrMowerHasInventory) {
+			t.Errorf("%s: got %v want %v", call, err, domain.ErrMowerHasInventory)
+		}
+	}
+
+	t.Run("keep a stocked model", func(t *testing.T) {
+		repos := factory(t)
+		unit := newUnit(t, repos, "SN-1")
+
+		_, err := repos.Catalog.Delete(ctx, unit.ModelID)
+		assertHasInventory(t, "Delete", err)
+
+		_, err = repos.Catalog.Purge(ctx, unit.ModelID)
+		assertHasInventory(t, "Purge", err)
+	})
+
+	t.Run("refuse units of a deleted model", func(t *testing.T) {
+		repos := factory(t)
+		unit := newUnit(t, repos, "SN-1")
+
+		_, err := repos.Inventory.Retire(ctx, unit.ID)
+		AssertNoError(t, err)
+
+		_, err = repos.Catalog.Delete(ctx, unit.ModelID)
+		AssertNoError(t, err)
+
+		_, err = repos.Inventory.Add(ctx, domain.RegisterUnitDTO{SerialNumber: "SN-2", ModelID: unit.ModelID, StoreID: unit.StoreID})
+		AssertError(t, err, domain.MowerNotFound(unit.ModelID).Error())
+	})
+
+	t.Run("keep retired serial numbers reserved through purges", func(t *testing.T) {
+		repos := factory(t)
+		unit := newUnit(t, repos, "SN-1")
+
+		_, err := repos.Inventory.Retire(ctx, unit.ID)
+		AssertNoError(t, err)
+
+		// purging would drop the retired unit along with its serial number
+		_, err = repos.Catalog.Purge(ctx, unit.ModelID)
+		assertHasInventory(t, "Purge", err)
+
+		other := newUnit(t, repos, "SN-2")
+
+		_, err = repos.Inventory.Add(ctx, domain.RegisterUnitDTO{SerialNumber: "SN-1", ModelID: other.ModelID, StoreID: other.StoreID})
+
+		if !errors.Is(err, domain.ErrSerialNumberTaken) {
+			t.Errorf("got %v want %v", err, domain.ErrSerialNumberTaken)
+		}
+	})
+}
@@ -0,0 +1,614 @@
+package lmTesting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// CatalogRepositoryFactory returns an empty repository stamping its mowers
+// with clock. It is called once per case of the contract.
+type CatalogRepositoryFactory func(t *testing.T, clock domain.Clock) domain.CatalogRepository
+
+// contractStart is the time of the contract clock, nanoseconds and zone
+// included so that repositories are checked to store microseconds in UTC.
+var contractStart = time.Date(2022, time.July, 14, 12, 0, 0, 123456789, time.FixedZone("CEST", 2*60*60))
+
+// RunCatalogRepositoryContract checks that the repositories made by factory
+// behave as every domain.CatalogRepository must, so that the service gets
+// the same answers whichever one it runs on. Every implementation calls it
+// from its own tests.
+func RunCatalogRepositoryContract(t *testing.T, factory CatalogRepositoryFactory) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	newRepo := func(t *testing.T) (domain.CatalogRepository, *FakeClock) {
+		t.Helper()
+
+		clock := NewFakeClock(contractStart)
+
+		return factory(t, clock), clock
+	}
+
+	add := func(t *testing.T, repo domain.CatalogRepository, name string) *domain.Mower {
+		t.Helper()
+
+		mower, err := repo.Add(ctx, domain.CreateMowerDTO{Name: name})
+		AssertNoError(t, err)
+
+		return mower
+	}
+
+	t.Run("add stamps a first version", func(t *testing.T) {
+		repo, _ := newRepo(t)
+		specs := domain.MowerSpecs{PowerSource: domain.PowerSourceBattery, CuttingWidthCm: 42, WeightKg: 21.5}
+
+		added, err := repo.Add(ctx, domain.CreateMowerDTO{Name: "M-90", Specs: specs})
+		AssertNoError(t, err)
+
+		if added.ID == "" {
+			t.Fatal("Add returned a mower without id")
+		}
+
+		want := &domain.Mower{
+			ID:        added.ID,
+			CreatedAt: domain.NewTimestamp(contractStart),
+			UpdatedAt: domain.NewTimestamp(contractStart),
+			Version:   1,
+			Name:      "M-90",
+			Specs:     specs,
+		}
+
+		assertContractMower(t, "Add", added, want)
+		assertContractFind(t, repo, added.ID, want)
+	})
+
+	t.Run("ids are never handed out twice", func(t *testing.T) {
+		repo, _ := newRepo(t)
+		seen := map[string]bool{}
+
+		for i := 0; i < 5; i++ {
+			mower := add(t, repo, fmt.Sprintf("M-%d", i))
+
+			if seen[mower.ID] {
+				t.Fatalf("got id %q twice", mower.ID)
+			}
+
+			seen[mower.ID] = true
+
+			if i == 3 {
+				_, err := repo.Purge(ctx, mower.ID)
+				AssertNoError(t, err)
+			}
+		}
+	})
+
+	t.Run("unknown ids find nothing", func(t *testing.T) {
+		repo, _ := newRepo(t)
+		add(t, repo, "M-90")
+
+		for _, id := range []string{"unknown", "6b3c2b9e-3f2a-4f43-9a4e-0f1d2f9b8c7a"} {
+			calls := map[string]func() (*domain.Mower, error){
+				"Find":    func() (*domain.Mower, error) { return repo.Find(ctx, id) },
+				"Patch":   func() (*domain.Mower, error) { return repo.Patch(ctx, id, domain.UpdateMowerDTO{Name: "M-150"}, 0) },
+				"Patch@3": func() (*domain.Mower, error) { return repo.Patch(ctx, id, domain.UpdateMowerDTO{Name: "M-150"}, 3) },
+				"Delete":  func() (*domain.Mower, error) { return repo.Delete(ctx, id) },
+				"Restore": func() (*domain.Mower, error) { return repo.Restore(ctx, id) },
+				"Purge":   func() (*domain.Mower, error) { return repo.Purge(ctx, id) },
+			}
+
+			for name, call := range calls {
+				got, err := call()
+
+				if got != nil || err != nil {
+					t.Errorf("%s(%q) = %s, %v want no mower and no error", name, id, formatMower(got), err)
+				}
+			}
+		}
+
+		got, err := repo.FindByName(ctx, "M-150")
+
+		if got != nil || err != nil {
+			t.Errorf("FindByName(%q) = %s, %v want no mower and no error", "M-150", formatMower(got), err)
+		}
+	})
+
+	t.Run("find by name ignores case", func(t *testing.T) {
+		repo, _ := newRepo(t)
+		add(t, repo, "M-90")
+		added := add(t, repo, "Mower-X")
+
+		got, err := repo.FindByName(ctx, "mower-x")
+		AssertNoError(t, err)
+
+		assertContractMower(t, "FindByName", got, added)
+	})
+
+	t.Run("names are unique regardless of case", func(t *testing.T) {
+		repo, _ := newRepo(t)
+		taken := add(t, repo, "M-90")
+		other := add(t, repo, "M-150")
+
+		added, err := repo.Add(ctx, domain.CreateMowerDTO{Name: "m-90"})
+
+		if added != nil || !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("Add of a taken name = %s, %v want %v", formatMower(added), err, domain.ErrValidation)
+		}
+
+		patched, err := repo.Patch(ctx, other.ID, domain.UpdateMowerDTO{Name: "M-90"}, 0)
+
+		if patched != nil || !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("Patch to a taken name = %s, %v want %v", formatMower(patched), err, domain.ErrValidation)
+		}
+
+		assertContractFind(t, repo, other.ID, other)
+
+		_, err = repo.Patch(ctx, taken.ID, domain.UpdateMowerDTO{Name: "m-90"}, 0)
+		AssertNoError(t, err)
+
+		const callers = 8
+
+		var wg sync.WaitGroup
+		errs := make([]error, callers)
+
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+				_, errs[i] = repo.Add(ctx, domain.CreateMowerDTO{Name: "M-480"})
+			}(i)
+		}
+
+		wg.Wait()
+
+		succeeded := 0
+
+		for _, err := range errs {
+			switch {
+			case err == nil:
+				succeeded++
+			case !errors.Is(err, domain.ErrValidation):
+				t.Errorf("concurrent Add of one name failed with %v want %v", err, domain.ErrValidation)
+			}
+		}
+
+		if succeeded != 1 {
+			t.Errorf("%d concurrent Adds of one name succeeded want 1", succeeded)
+		}
+	})
+
+	t.Run("patch changes the given fields", func(t *testing.T) {
+		repo, clock := newRepo(t)
+		specs := domain.MowerSpecs{PowerSource: domain.PowerSourcePetrol, CuttingWidthCm: 42}
+
+		added, err := repo.Add(ctx, domain.CreateMowerDTO{Name: "M-90", Specs: specs})
+		AssertNoError(t, err)
+
+		clock.Advance(time.Minute)
+
+		renamed, err := repo.Patch(ctx, added.ID, domain.UpdateMowerDTO{Name: "M-150"}, 0)
+		AssertNoError(t, err)
+
+		want := *added
+		want.Name = "M-150"
+		want.Version = 2
+		want.UpdatedAt = domain.NewTimestamp(contractStart.Add(time.Minute))
+
+		assertContractMower(t, "Patch of the name", renamed, &want)
+
+		clock.Advance(time.Minute)
+		width := 46
+
+		widened, err := repo.Patch(ctx, added.ID, domain.UpdateMowerDTO{Specs: &domain.MowerSpecsPatch{CuttingWidthCm: &width}}, 2)
+		AssertNoError(t, err)
+
+		want.Specs.CuttingWidthCm = 46
+		want.Version = 3
+		want.UpdatedAt = domain.NewTimestamp(contractStart.Add(2 * time.Minute))
+
+		assertContractMower(t, "Patch of the specs", widened, &want)
+		assertContractFind(t, repo, added.ID, &want)
+	})
+
+	t.Run("patch refuses a stale version", func(t *testing.T) {
+		repo, _ := newRepo(t)
+		added := add(t, repo, "M-90")
+
+		patched, err := repo.Patch(ctx, added.ID, domain.UpdateMowerDTO{Name: "M-150"}, 1)
+		AssertNoError(t, err)
+
+		got, err := repo.Patch(ctx, added.ID, domain.UpdateMowerDTO{Name: "M-480"}, 1)
+
+		if got != nil || !errors.Is(err, domain.ErrVersionConflict) {
+			t.Errorf("Patch at version 1 of a mower at version 2 = %s, %v want %v", formatMower(got), err, domain.ErrVersionConflict)
+		}
+
+		assertContractFind(t, repo, added.ID, patched)
+	})
+
+	t.Run("soft delete then restore", func(t *testing.T) {
+		repo, clock := newRepo(t)
+		kept := add(t, repo, "M-90")
+		clock.Advance(time.Minute)
+		added := add(t, repo, "M-150")
+
+		clock.Advance(time.Minute)
+		deleted, err := repo.Delete(ctx, added.ID)
+		AssertNoError(t, err)
+
+		want := *added
+		want.DeletedAt = domain.NewTimestamp(contractStart.Add(2 * time.Minute))
+		want.UpdatedAt = want.DeletedAt
+		want.Version = 2
+
+		assertContractMower(t, "Delete", deleted, &want)
+
+		clock.Advance(time.Minute)
+		again, err := repo.Delete(ctx, added.ID)
+		AssertNoError(t, err)
+
+		assertContractMower(t, "Delete of a deleted mower", again, &want)
+		assertContractFind(t, repo, added.ID, &want)
+
+		byName, err := repo.FindByName(ctx, "M-150")
+		AssertNoError(t, err)
+
+		assertContractMower(t, "FindByName of a deleted mower", byName, &want)
+
+		availabilities := []struct {
+			name         string
+			availability domain.Availability
+			want         []*domain.Mower
+		}{
+			{"only available", domain.OnlyAvailable, []*domain.Mower{kept}},
+			{"only deleted", domain.OnlyDeleted, []*domain.Mower{&want}},
+			{"any availability", domain.AnyAvailability, []*domain.Mower{kept, &want}},
+		}
+
+		for _, a := range availabilities {
+			got, err := repo.FindAvailableMowers(ctx, domain.MowerQuery{Availability: a.availability})
+			AssertNoError(t, err)
+
+			assertContractMowers(t, "FindAvailableMowers "+a.name, got, a.want)
+		}
+
+		assertContractPage(t, repo, domain.PageQuery{Limit: 10}, []*domain.Mower{kept})
+		assertContractPage(t, repo, domain.PageQuery{Limit: 10, MowerQuery: domain.MowerQuery{Availability: domain.AnyAvailability}}, []*domain.Mower{kept, &want})
+
+		clock.Advance(time.Minute)
+		restored, err := repo.Restore(ctx, added.ID)
+		AssertNoError(t, err)
+
+		want.DeletedAt = nil
+		want.UpdatedAt = domain.NewTimestamp(contractStart.Add(4 * time.Minute))
+		want.Version = 3
+
+		assertContractMower(t, "Restore", restored, &want)
+
+		clock.Advance(time.Minute)
+		again, err = repo.Restore(ctx, added.ID)
+		AssertNoError(t, err)
+
+		assertContractMower(t, "Restore of a live mower", again, &want)
+		assertContractFind(t, repo, added.ID, &want)
+	})
+
+	t.Run("purge removes the mower", func(t *testing.T) {
+		repo, _ := newRepo(t)
+		kept := add(t, repo, "M-90")
+		added := add(t, repo, "M-150")
+
+		deleted, err := repo.Delete(ctx, added.ID)
+		AssertNoError(t, err)
+
+		purged, err := repo.Purge(ctx, added.ID)
+		AssertNoError(t, err)
+
+		assertContractMower(t, "Purge", purged, deleted)
+		assertContractFind(t, repo, added.ID, nil)
+
+		byName, err := repo.FindByName(ctx, "M-150")
+		AssertNoError(t, err)
+
+		assertContractMower(t, "FindByName of a purged mower", byName, nil)
+
+		all, err := repo.FindAvailableMowers(ctx, domain.MowerQuery{Availability: domain.AnyAvailability})
+		AssertNoError(t, err)
+
+		assertContractMowers(t, "FindAvailableMowers after Purge", all, []*domain.Mower{kept})
+
+		again, err := repo.Purge(ctx, added.ID)
+		AssertNoError(t, err)
+
+		assertContractMower(t, "Purge of a purged mower", again, nil)
+	})
+
+	t.Run("list in creation order", func(t *testing.T) {
+		repo, clock := newRepo(t)
+		mowers := []*domain.Mower{}
+
+		// added out of order, two of them at the same instant
+		for i, offset := range []time.Duration{2 * time.Minute, 0, time.Minute, time.Minute, 3 * time.Minute} {
+			clock.Set(contractStart.Add(offset))
+			mowers = append(mowers, add(t, repo, fmt.Sprintf("M-%d", i)))
+		}
+
+		want := append([]*domain.Mower{}, mowers...)
+
+		sort.SliceStable(want, func(i, j int) bool {
+			ti, tj := time.Time(*want[i].CreatedAt), time.Time(*want[j].CreatedAt)
+			return ti.Before(tj) || (ti.Equal(tj) && want[i].ID < want[j].ID)
+		})
+
+		got, err := repo.FindAvailableMowers(ctx, domain.MowerQuery{})
+		AssertNoError(t, err)
+
+		assertContractMowers(t, "FindAvailableMowers", got, want)
+		assertContractPage(t, repo, domain.PageQuery{Limit: 10}, want)
+
+		picked, err := repo.FindAvailableMowers(ctx, domain.MowerQuery{IDs: []string{mowers[0].ID, mowers[1].ID, mowers[0].ID}})
+		AssertNoError(t, err)
+
+		assertContractMowers(t, "FindAvailableMowers of some ids", picked, []*domain.Mower{mowers[1], mowers[0]})
+	})
+
+	t.Run("page through a filtered and sorted listing", func(t *testing.T) {
+		repo, clock := newRepo(t)
+
+		// widths shared so that the pages split ties on name, named against
+		// the creation order
+		for i, width := range []int{40, 51, 40, 46, 51, 40, 35} {
+			clock.Advance(time.Minute)
+
+			mower, err := repo.Add(ctx, domain.CreateMowerDTO{Name: fmt.Sprintf("M-%d", 90-10*i), Specs: domain.MowerSpecs{
+				PowerSource:    []domain.PowerSource{domain.PowerSourceBattery, domain.PowerSourcePetrol}[i%2],
+				CuttingWidthCm: width,
+			}})
+			AssertNoError(t, err)
+
+			if i == 4 {
+				_, err = repo.Delete(ctx, mower.ID)
+				AssertNoError(t, err)
+			}
+		}
+
+		filter, err := domain.NewMowerFilter("cuttingWidthCm", domain.OpGte, "40")
+		AssertNoError(t, err)
+
+		sorting, err := domain.ParseSort("-cuttingWidthCm,name")
+		AssertNoError(t, err)
+
+		query := domain.PageQuery{MowerQuery: domain.MowerQuery{Filters: []domain.MowerFilter{filter}, Sort: sorting}, Limit: 2, CountTotal: true}
+
+		want, err := repo.FindAvailableMowers(ctx, query.MowerQuery)
+		AssertNoError(t, err)
+
+		var forward, backward []*domain.Mower
+		var last *domain.MowerPage
+
+		for page := (*domain.MowerPage)(nil); page == nil || page.Next != nil; {
+			if page != nil {
+				query.Cursor = page.Next
+			}
+
+			page, err = repo.FindMowerPage(ctx, query)
+			AssertNoError(t, err)
+
+			if page.Total == nil || *page.Total != len(want) {
+				t.Fatalf("FindMowerPage counted %v mowers want %d", page.Total, len(want))
+			}
+
+			forward, last = append(forward, page.Mowers...), page
+		}
+
+		assertContractMowers(t, "FindMowerPage forward", forward, want)
+
+		for page := last; page.Prev != nil; {
+			query.Cursor = page.Prev
+
+			page, err = repo.FindMowerPage(ctx, query)
+			AssertNoError(t, err)
+
+			backward = append(append([]*domain.Mower{}, page.Mowers...), backward...)
+		}
+
+		assertContractMowers(t, "FindMowerPage backward", append(backward, last.Mowers...), want)
+	})
+
+	t.Run("results are copies", func(t *testing.T) {
+		repo, _ := newRepo(t)
+		added := add(t, repo, "M-90")
+		want := *added
+		want.CreatedAt = domain.NewTimestamp(contractStart)
+		want.UpdatedAt = domain.NewTimestamp(contractStart)
+
+		tamper := func(mower *domain.Mower) {
+			mower.Name = "tampered"
+			mower.Version = 42
+			*mower.CreatedAt = domain.Timestamp(time.Time{})
+			*mower.UpdatedAt = domain.Timestamp(time.Time{})
+		}
+
+		tamper(added)
+
+		found, err := repo.Find(ctx, want.ID)
+		AssertNoError(t, err)
+		tamper(found)
+
+		listed, err := repo.FindAvailableMowers(ctx, domain.MowerQuery{})
+		AssertNoError(t, err)
+		tamper(listed[0])
+
+		assertContractFind(t, repo, want.ID, &want)
+	})
+
+	t.Run("concurrent adds and patches", func(t *testing.T) {
+		repo, _ := newRepo(t)
+
+		const workers = 16
+
+		var wg sync.WaitGroup
+		added := make([]*domain.Mower, workers)
+		errs := make([]error, workers)
+
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+				added[i], errs[i] = repo.Add(ctx, domain.CreateMowerDTO{Name: fmt.Sprintf("M-%d", i)})
+			}(i)
+		}
+
+		wg.Wait()
+
+		ids := map[string]bool{}
+
+		for i, err := range errs {
+			AssertNoError(t, err)
+
+			if ids[added[i].ID] {
+				t.Fatalf("got id %q twice", added[i].ID)
+			}
+
+			ids[added[i].ID] = true
+		}
+
+		all, err := repo.FindAvailableMowers(ctx, domain.MowerQuery{})
+		AssertNoError(t, err)
+
+		if len(all) != workers {
+			t.Errorf("got %d mowers want %d", len(all), workers)
+		}
+
+		target := added[0]
+		conflicts := make([]error, workers)
+
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+				_, conflicts[i] = repo.Patch(ctx, target.ID, domain.UpdateMowerDTO{Name: fmt.Sprintf("M-%d-patched", i)}, 1)
+			}(i)
+		}
+
+		wg.Wait()
+
+		succeeded := 0
+
+		for _, err := range conflicts {
+			switch {
+			case err == nil:
+				succeeded++
+			case !errors.Is(err, domain.ErrVersionConflict):
+				t.Errorf("got %v want nil or %v", err, domain.ErrVersionConflict)
+			}
+		}
+
+		if succeeded != 1 {
+			t.Errorf("%d patches at version 1 succeeded want exactly 1", succeeded)
+		}
+
+		patched, err := repo.Find(ctx, target.ID)
+		AssertNoError(t, err)
+
+		if patched.Version != 2 {
+			t.Errorf("got version %d want 2", patched.Version)
+		}
+	})
+}
+
+func assertContractFind(t *testing.T, repo domain.CatalogRepository, id string, want *domain.Mower) {
+	t.Helper()
+
+	got, err := repo.Find(context.Background(), id)
+	AssertNoError(t, err)
+
+	assertContractMower(t, "Find", got, want)
+}
+
+func assertContractPage(t *testing.T, repo domain.CatalogRepository, query domain.PageQuery, want []*domain.Mower) {
+	t.Helper()
+
+	page, err := repo.FindMowerPage(context.Background(), query)
+	AssertNoError(t, err)
+
+	assertContractMowers(t, fmt.Sprintf("FindMowerPage with availability %d", query.Availability), page.Mowers, want)
+}
+
+func assertContractMower(t *testing.T, call string, got, want *domain.Mower) {
+	t.Helper()
+
+	if formatMower(got) != formatMower(want) {
+		t.Errorf("%s returned\n  got:  %s\n  want: %s", call, formatMower(got), formatMower(want))
+	}
+}
+
+// assertContractMowers reports the mowers that differ line by line, "-"
+// for the expected ones and "+" for the ones returned.
+func assertContractMowers(t *testing.T, call string, got, want []*domain.Mower) {
+	t.Helper()
+
+	var diff strings.Builder
+	differs := len(got) != len(want)
+
+	for i := 0; i < len(got) || i < len(want); i++ {
+		var g, w string
+
+		if i < len(got) {
+			g = formatMower(got[i])
+		}
+
+		if i < len(want) {
+			w = formatMower(want[i])
+		}
+
+		if g == w {
+			fmt.Fprintf(&diff, "    %s\n", g)
+			continue
+		}
+
+		differs = true
+
+		if i < len(want) {
+			fmt.Fprintf(&diff, "  - %s\n", w)
+		}
+
+		if i < len(got) {
+			fmt.Fprintf(&diff, "  + %s\n", g)
+		}
+	}
+
+	if differs {
+		t.Errorf("%s returned %d mowers want %d (- want, + got):\n%s", call, len(got), len(want), diff.String())
+	}
+}
+
+// formatMower prints every field of mower, timestamps at full precision.
+func formatMower(mower *domain.Mower) string {
+	if mower == nil {
+		return "<no mower>"
+	}
+
+	return fmt.Sprintf("{id:%s name:%q version:%d created:%s updated:%s deleted:%s specs:%+v}",
+		mower.ID, mower.Name, mower.Version,
+		formatTimestamp(mower.CreatedAt), formatTimestamp(mower.UpdatedAt), formatTimestamp(mower.DeletedAt),
+		mower.Specs)
+}
+
+func formatTimestamp(t *domain.Timestamp) string {
+	if t == nil {
+		return "-"
+	}
+
+	return time.Time(*t).Format(time.RFC3339Nano)
+}
@@ -1,55 +1,613 @@
 package lmTesting
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"jrobic/lawn-mower/catalog-service/domain"
 	"net/http"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
+// StubCatalogRepository is a CatalogRepository over the Mowers slice. Like
+// the real repositories it is safe for concurrent use and hands out copies,
+// see RunCatalogRepositoryContract.
 type StubCatalogRepository struct {
 	Mowers []*domain.Mower
+	Clock  domain.Clock
+	IDs    domain.IDGenerator
+	// Delay makes every call take that long, like a slow database would,
+	// unless ctx is done first.
+	Delay time.Duration
+	// Inventory, when set, holds the units that keep their model from being
+	// deleted, while live, or purged, like in the real repositories.
+	Inventory *StubInventoryRepository
+
+	lock   sync.Mutex
+	lastID int
+}
+
+// newID uses IDs when set, otherwise it counts up from the number of seeded
+// mowers without ever handing out the same id twice.
+func (r *StubCatalogRepository) newID() string {
+	if r.IDs != nil {
+		return r.IDs.NewID()
+	}
+
+	if r.lastID == 0 {
+		r.lastID = len(r.Mowers)
+	}
+
+	r.lastID++
+
+	return fmt.Sprint(r.lastID)
+}
+
+// wait takes Delay, returning the error of ctx when it is done first.
+func (r *StubCatalogRepository) wait(ctx context.Context) error {
+	if r.Delay == 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(r.Delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *StubCatalogRepository) now() *domain.Timestamp {
+	if r.Clock == nil {
+		return domain.Stamp(domain.SystemClock{})
+	}
+
+	return domain.Stamp(r.Clock)
 }
 
-func (r *StubCatalogRepository) Find(id string) (*domain.Mower, error) {
+// find returns the index of the mower with id, -1 if there is none.
+// nameTaken reports whether a mower other than exceptID has name,
+// regardless of case. The caller holds the lock.
+func (r *StubCatalogRepository) nameTaken(name string, exceptID string) bool {
+	for _, mower := range r.Mowers {
+		if mower.ID != exceptID && strings.EqualFold(mower.Name, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *StubCatalogRepository) find(id string) int {
 	for i, mower := range r.Mowers {
 		if mower.ID == id {
-			return r.Mowers[i], nil
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (r *StubCatalogRepository) Find(ctx context.Context, id string) (*domain.Mower, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if i := r.find(id); i >= 0 {
+		return copyMower(r.Mowers[i]), nil
+	}
+
+	return nil, nil
+}
+
+func (r *StubCatalogRepository) FindByName(ctx context.Context, name string) (*domain.Mower, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, mower := range r.Mowers {
+		if strings.EqualFold(mower.Name, name) {
+			return copyMower(mower), nil
 		}
 	}
+
 	return nil, nil
 }
 
-func (r *StubCatalogRepository) Add(input domain.CreateMowerDTO) (*domain.Mower, error) {
-	id := fmt.Sprint(len(r.Mowers) + 1)
+func (r *StubCatalogRepository) Add(ctx context.Context, input domain.CreateMowerDTO) (*domain.Mower, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.nameTaken(input.Name, "") {
+		return nil, domain.NameTaken()
+	}
+
+	now := r.now()
 
 	mower := &domain.Mower{
-		ID:   id,
-		Name: input.Name,
+		ID:        r.newID(),
+		CreatedAt: now,
+		UpdatedAt: copyTimestamp(now),
+		Version:   1,
+		Name:      input.Name,
+		Specs:     input.Specs,
 	}
 
 	r.Mowers = append(r.Mowers, mower)
 
-	return mower, nil
+	return copyMower(mower), nil
 }
 
-func (r *StubCatalogRepository) Patch(id string, input domain.UpdateMowerDTO) (mower *domain.Mower, err error) {
-	for i, mower := range r.Mowers {
-		if mower.ID == id {
-			r.Mowers[i].Name = input.Name
-			mower = r.Mowers[i]
-			return mower, err
+func (r *StubCatalogRepository) Patch(ctx context.Context, id string, input domain.UpdateMowerDTO, expectedVersion int64) (*domain.Mower, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	i := r.find(id)
+
+	if i < 0 {
+		return nil, nil
+	}
+
+	mower := r.Mowers[i]
+
+	if expectedVersion != 0 && mower.Version != expectedVersion {
+		return nil, domain.ErrVersionConflict
+	}
+
+	if input.Name != "" && r.nameTaken(input.Name, id) {
+		return nil, domain.NameTaken()
+	}
+
+	mower.Version++
+	if input.Name != "" {
+		mower.Name = input.Name
+	}
+	if input.Specs != nil {
+		mower.Specs = mower.Specs.Apply(*input.Specs)
+	}
+	mower.UpdatedAt = r.now()
+
+	return copyMower(mower), nil
+}
+
+func (r *StubCatalogRepository) Delete(ctx context.Context, id string) (*domain.Mower, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	i := r.find(id)
+
+	if i < 0 {
+		return nil, nil
+	}
+
+	if r.Inventory != nil && r.Inventory.holds(id, false) {
+		return nil, domain.ErrMowerHasInventory
+	}
+
+	mower := r.Mowers[i]
+
+	if mower.DeletedAt == nil {
+		now := r.now()
+		mower.DeletedAt = now
+		mower.UpdatedAt = copyTimestamp(now)
+		mower.Version++
+	}
+
+	return copyMower(mower), nil
+}
+
+func (r *StubCatalogRepository) Restore(ctx context.Context, id string) (*domain.Mower, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	i := r.find(id)
+
+	if i < 0 {
+		return nil, nil
+	}
+
+	mower := r.Mowers[i]
+
+	if mower.DeletedAt != nil {
+		mower.DeletedAt = nil
+		mower.UpdatedAt = r.now()
+		mower.Version++
+	}
+
+	return copyMower(mower), nil
+}
+
+// Purge leaves the slice the mowers were seeded with as it was.
+func (r *StubCatalogRepository) Purge(ctx context.Context, id string) (*domain.Mower, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	i := r.find(id)
+
+	if i < 0 {
+		return nil, nil
+	}
+
+	if r.Inventory != nil && r.Inventory.holds(id, true) {
+		return nil, domain.ErrMowerHasInventory
+	}
+
+	mower := r.Mowers[i]
+	remaining := make([]*domain.Mower, 0, len(r.Mowers)-1)
+	r.Mowers = append(append(remaining, r.Mowers[:i]...), r.Mowers[i+1:]...)
+
+	return copyMower(mower), nil
+}
+
+func (r *StubCatalogRepository) FindAvailableMowers(ctx context.Context, query domain.MowerQuery) ([]*domain.Mower, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return copyMowers(domain.QueryMowers(r.Mowers, query)), nil
+}
+
+func (r *StubCatalogRepository) FindMowerPage(ctx context.Context, query domain.PageQuery) (*domain.MowerPage, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	page := domain.PageMowers(r.Mowers, query)
+	page.Mowers = copyMowers(page.Mowers)
+
+	return page, nil
+}
+
+// copyMower returns a copy of mower sharing nothing with it.
+func copyMower(mower *domain.Mower) *domain.Mower {
+	copied := *mower
+	copied.CreatedAt = copyTimestamp(mower.CreatedAt)
+	copied.UpdatedAt = copyTimestamp(mower.UpdatedAt)
+	copied.DeletedAt = copyTimestamp(mower.DeletedAt)
+
+	return &copied
+}
+
+func copyMowers(mowers []*domain.Mower) []*domain.Mower {
+	copied := make([]*domain.Mower, len(mowers))
+
+	for i, mower := range mowers {
+		copied[i] = copyMower(mower)
+	}
+
+	return copied
+}
+
+func copyTimestamp(t *domain.Timestamp) *domain.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	copied := *t
+
+	return &copied
+}
+
+type StubStoreRepository struct {
+	Stores []*domain.Store
+
+	lastID int
+}
+
+func (r *StubStoreRepository) Find(ctx context.Context, id string) (*domain.Store, error) {
+	for i, store := range r.Stores {
+		if store.ID == id {
+			return r.Stores[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *StubStoreRepository) Add(ctx context.Context, input domain.CreateStoreDTO) (*domain.Store, error) {
+	if r.lastID == 0 {
+		r.lastID = len(r.Stores)
+	}
+
+	r.lastID++
+
+	store := &domain.Store{
+		ID:   fmt.Sprint(r.lastID),
+		Name: input.Name,
+	}
+
+	r.Stores = append(r.Stores, store)
+
+	return store, nil
+}
+
+func (r *StubStoreRepository) Patch(ctx context.Context, id string, input domain.UpdateStoreDTO) (*domain.Store, error) {
+	for i, store := range r.Stores {
+		if store.ID == id {
+			if input.Name != "" {
+				r.Stores[i].Name = input.Name
+			}
+			return r.Stores[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+type StubInventoryRepository struct {
+	Units []*domain.InventoryUnit
+
+	lastID int
+}
+
+func (r *StubInventoryRepository) Find(ctx context.Context, id string) (*domain.InventoryUnit, error) {
+	for i, unit := range r.Units {
+		if unit.ID == id {
+			return r.Units[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *StubInventoryRepository) Add(ctx context.Context, input domain.RegisterUnitDTO) (*domain.InventoryUnit, error) {
+	for _, unit := range r.Units {
+		if unit.SerialNumber == input.SerialNumber {
+			return nil, domain.ErrSerialNumberTaken
+		}
+	}
+
+	if r.lastID == 0 {
+		r.lastID = len(r.Units)
+	}
+
+	r.lastID++
+
+	unit := &domain.InventoryUnit{
+		ID:           fmt.Sprint(r.lastID),
+		SerialNumber: input.SerialNumber,
+		ModelID:      input.ModelID,
+		StoreID:      input.StoreID,
+	}
+
+	r.Units = append(r.Units, unit)
+
+	return unit, nil
+}
+
+// holds reports whether live units, or retired ones too when retired is set,
+// are of the model.
+func (r *StubInventoryRepository) holds(modelID string, retired bool) bool {
+	for _, unit := range r.Units {
+		if unit.ModelID == modelID && (retired || unit.DeletedAt == nil) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *StubInventoryRepository) Retire(ctx context.Context, id string) (*domain.InventoryUnit, error) {
+	for i, unit := range r.Units {
+		if unit.ID == id {
+			if unit.DeletedAt == nil {
+				r.Units[i].DeletedAt = domain.NewTimestamp(time.Now())
+			}
+			return r.Units[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *StubInventoryRepository) Move(ctx context.Context, id string, storeID string) (*domain.InventoryUnit, error) {
+	for i, unit := range r.Units {
+		if unit.ID == id {
+			r.Units[i].StoreID = storeID
+			return r.Units[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *StubInventoryRepository) FindByStore(ctx context.Context, storeID string) ([]*domain.InventoryUnit, error) {
+	units := []*domain.InventoryUnit{}
+
+	for _, unit := range r.Units {
+		if unit.StoreID == storeID && unit.DeletedAt == nil {
+			units = append(units, unit)
+		}
+	}
+
+	return units, nil
+}
+
+func (r *StubInventoryRepository) FindByModel(ctx context.Context, modelID string) ([]*domain.InventoryUnit, error) {
+	units := []*domain.InventoryUnit{}
+
+	for _, unit := range r.Units {
+		if unit.ModelID == modelID && unit.DeletedAt == nil {
+			units = append(units, unit)
+		}
+	}
+
+	return units, nil
+}
+
+func (r *StubInventoryRepository) FindStockedModelIDs(ctx context.Context, storeID string) ([]string, error) {
+	seen := map[string]bool{}
+	modelIDs := []string{}
+
+	for _, unit := range r.Units {
+		if unit.StoreID == storeID && unit.DeletedAt == nil && !seen[unit.ModelID] {
+			seen[unit.ModelID] = true
+			modelIDs = append(modelIDs, unit.ModelID)
+		}
+	}
+
+	return modelIDs, nil
+}
+
+// StubSearchIndex keeps the indexed mowers by id and finds them by name,
+// regardless of case.
+type StubSearchIndex struct {
+	Mowers map[string]*domain.Mower
+	// Failures is the number of the next calls to Index or Remove that fail
+	// with ErrIndexFailed.
+	Failures int
+
+	lock sync.Mutex
+}
+
+// ErrIndexFailed is the error of the StubSearchIndex calls made to fail.
+var ErrIndexFailed = errors.New("index failed")
+
+// fail uses up one of the failures. The caller holds the lock.
+func (s *StubSearchIndex) fail() error {
+	if s.Failures == 0 {
+		return nil
+	}
+
+	s.Failures--
+
+	return ErrIndexFailed
+}
+
+func (s *StubSearchIndex) Index(ctx context.Context, mower *domain.Mower) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.fail(); err != nil {
+		return err
+	}
+
+	if s.Mowers == nil {
+		s.Mowers = map[string]*domain.Mower{}
+	}
+
+	s.Mowers[mower.ID] = mower
+
+	return nil
+}
+
+func (s *StubSearchIndex) Remove(ctx context.Context, id string, version int64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.fail(); err != nil {
+		return err
+	}
+
+	delete(s.Mowers, id)
+
+	return nil
+}
+
+func (s *StubSearchIndex) Search(ctx context.Context, text string, limit int) ([]domain.SearchHit, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	hits := []domain.SearchHit{}
+
+	for _, mower := range s.Mowers {
+		if len(hits) < limit && strings.Contains(strings.ToLower(mower.Name), strings.ToLower(text)) {
+			hits = append(hits, domain.SearchHit{Mower: mower, Score: 1})
 		}
 	}
 
-	return mower, err
+	return hits, nil
+}
+
+func (s *StubSearchIndex) Suggest(ctx context.Context, prefix string, limit int) ([]string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	names := []string{}
+
+	for _, mower := range s.Mowers {
+		if len(names) < limit && strings.HasPrefix(strings.ToLower(mower.Name), strings.ToLower(prefix)) {
+			names = append(names, mower.Name)
+		}
+	}
+
+	return names, nil
+}
+
+// FakeClock is a domain.Clock under test control. Every call to Now returns
+// the current time then moves it forward by Step.
+type FakeClock struct {
+	Step time.Duration
+
+	lock sync.Mutex
+	now  time.Time
+}
+
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := c.now
+	c.now = c.now.Add(c.Step)
+
+	return now
 }
 
-func (r *StubCatalogRepository) FindAvailableMowers() ([]*domain.Mower, error) {
-	return r.Mowers, nil
+func (c *FakeClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+func (c *FakeClock) Set(t time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.now = t
 }
 
 func AssertNoError(t testing.TB, err error) {
@@ -121,6 +679,18 @@ func GetMowerFromResponse(t testing.TB, body io.Reader) (mower domain.Mower) {
 	return
 }
 
+func GetStoreFromResponse(t testing.TB, body io.Reader) (store domain.Store) {
+	t.Helper()
+
+	err := json.NewDecoder(body).Decode(&store)
+
+	if err != nil {
+		t.Fatalf("Unable to parse response from server %q into Store, '%v'", body, err)
+	}
+
+	return
+}
+
 func GetCatalogFromResponse(t testing.TB, body io.Reader) (mowers []*domain.Mower) {
 	t.Helper()
 
@@ -132,3 +702,27 @@ func GetCatalogFromResponse(t testing.TB, body io.Reader) (mowers []*domain.Mowe
 
 	return
 }
+
+func GetUnitFromResponse(t testing.TB, body io.Reader) (unit domain.InventoryUnit) {
+	t.Helper()
+
+	err := json.NewDecoder(body).Decode(&unit)
+
+	if err != nil {
+		t.Fatalf("Unable to parse response from server %q into InventoryUnit, '%v'", body, err)
+	}
+
+	return
+}
+
+func GetInventoryFromResponse(t testing.TB, body io.Reader) (units []*domain.InventoryUnit) {
+	t.Helper()
+
+	err := json.NewDecoder(body).Decode(&units)
+
+	if err != nil {
+		t.Fatalf("Unable to parse response from server %q into Inventory, '%v'", body, err)
+	}
+
+	return
+}
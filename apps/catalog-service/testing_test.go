@@ -0,0 +1,12 @@
+package lmTesting
+
+import (
+	"jrobic/lawn-mower/catalog-service/domain"
+	"testing"
+)
+
+func TestStubCatalogRepositoryContract(t *testing.T) {
+	RunCatalogRepositoryContract(t, func(t *testing.T, clock domain.Clock) domain.CatalogRepository {
+		return &StubCatalogRepository{Clock: clock}
+	})
+}
@@ -1,22 +1,81 @@
 package usecase
 
 import (
+	"context"
 	"jrobic/lawn-mower/catalog-service/domain"
 )
 
 type CatalogService interface {
-	CreateMower(input domain.CreateMowerDTO) (*domain.Mower, error)
-	UpdateMower(id string, input domain.UpdateMowerDTO) (*domain.Mower, error)
-	GetMower(id string) (*domain.Mower, error)
-	GetAvailableMowers() ([]*domain.Mower, error)
+	CreateMower(ctx context.Context, input domain.CreateMowerDTO) (*domain.Mower, error)
+	UpdateMower(ctx context.Context, id string, input domain.UpdateMowerDTO, expectedVersion int64) (*domain.Mower, error)
+	GetMower(ctx context.Context, id string) (*domain.Mower, error)
+	DeleteMower(ctx context.Context, id string) (*domain.Mower, error)
+	RestoreMower(ctx context.Context, id string) (*domain.Mower, error)
+	PurgeMower(ctx context.Context, id string) error
+	GetAvailableMowers(ctx context.Context, query domain.MowerQuery) ([]*domain.Mower, error)
+	GetMowerPage(ctx context.Context, query domain.PageQuery) (*domain.MowerPage, error)
+	SearchMowers(ctx context.Context, text string, limit int) ([]domain.SearchHit, error)
+	SuggestMowers(ctx context.Context, prefix string, limit int) ([]string, error)
+
+	CreateStore(ctx context.Context, input domain.CreateStoreDTO) (*domain.Store, error)
+	UpdateStore(ctx context.Context, id string, input domain.UpdateStoreDTO) (*domain.Store, error)
+	GetStore(ctx context.Context, id string) (*domain.Store, error)
+	GetStoreMowers(ctx context.Context, storeID string) ([]*domain.Mower, error)
+
+	RegisterUnit(ctx context.Context, storeID string, input domain.RegisterUnitDTO) (*domain.InventoryUnit, error)
+	RetireUnit(ctx context.Context, storeID string, unitID string) (*domain.InventoryUnit, error)
+	MoveUnit(ctx context.Context, storeID string, unitID string, toStoreID string) (*domain.InventoryUnit, error)
+	GetStoreInventory(ctx context.Context, storeID string) ([]*domain.InventoryUnit, error)
+	GetModelInventory(ctx context.Context, modelID string) ([]*domain.InventoryUnit, error)
 }
 
 type LMCatalogService struct {
-	repo domain.CatalogRepository
+	repo      domain.CatalogRepository
+	stores    domain.StoreRepository
+	inventory domain.InventoryRepository
+	index     domain.SearchIndex
+	reindex   *reindexer
+	validator *domain.MowerValidator
+}
+
+type ServiceOption func(*LMCatalogService)
+
+// WithStoreRepository enables the store use cases, which otherwise fail with
+// domain.ErrStoresUnavailable.
+func WithStoreRepository(stores domain.StoreRepository) ServiceOption {
+	return func(lm *LMCatalogService) {
+		lm.stores = stores
+	}
 }
 
-func NewCatalogService(repo domain.CatalogRepository) *LMCatalogService {
-	return &LMCatalogService{
-		repo: repo,
+// WithInventoryRepository enables the inventory use cases, which otherwise
+// fail with domain.ErrInventoryUnavailable. Mowers can only be deleted while
+// no live unit of them is left in inventory.
+func WithInventoryRepository(inventory domain.InventoryRepository) ServiceOption {
+	return func(lm *LMCatalogService) {
+		lm.inventory = inventory
 	}
 }
+
+// WithSearchIndex enables the search use cases, which otherwise fail with
+// domain.ErrSearchUnavailable. Every change of a mower goes to the index,
+// retried in the background when the index fails.
+func WithSearchIndex(index domain.SearchIndex) ServiceOption {
+	return func(lm *LMCatalogService) {
+		lm.index = index
+	}
+}
+
+func NewCatalogService(repo domain.CatalogRepository, opts ...ServiceOption) *LMCatalogService {
+	lm := &LMCatalogService{
+		repo:      repo,
+		reindex:   &reindexer{pending: map[string]int64{}, delay: reindexDelay},
+		validator: domain.NewMowerValidator(repo),
+	}
+
+	for _, opt := range opts {
+		opt(lm)
+	}
+
+	return lm
+}
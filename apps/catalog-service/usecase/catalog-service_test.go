@@ -1,14 +1,18 @@
 package usecase
 
 import (
+	"context"
+	"errors"
 	lmTesting "jrobic/lawn-mower/catalog-service"
 	domain "jrobic/lawn-mower/catalog-service/domain"
 	"reflect"
-	"strings"
+	"time"
 
 	"testing"
 )
 
+var testNow = time.Date(2022, time.July, 14, 10, 0, 0, 0, time.UTC)
+
 func TestGetMower(t *testing.T) {
 	t.Run("catalog: return a mower", func(t *testing.T) {
 		want := &domain.Mower{ID: "1", Name: "M-350"}
@@ -20,7 +24,7 @@ func TestGetMower(t *testing.T) {
 
 		service := NewCatalogService(repo)
 
-		got, err := service.GetMower("1")
+		got, err := service.GetMower(context.Background(), "1")
 
 		lmTesting.AssertNoError(t, err)
 		lmTesting.AssertMowerEquals(t, *got, *want)
@@ -38,14 +42,50 @@ func TestGetMower(t *testing.T) {
 
 		service := NewCatalogService(repo)
 
-		_, err := service.GetMower(IDNotFound)
+		_, err := service.GetMower(context.Background(), IDNotFound)
 
-		got := strings.Replace(domain.ErrMowerNotFound, "%v", IDNotFound, 1)
+		got := domain.MowerNotFound(IDNotFound).Error()
 
 		lmTesting.AssertError(t, err, got)
 	})
 }
 
+func TestCancelledContext(t *testing.T) {
+	repo := &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{{ID: "1", Name: "M-350"}}, Delay: time.Second}
+	service := NewCatalogService(repo)
+
+	t.Run("catalog: a cancelled context aborts the repository call", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+		}()
+
+		start := time.Now()
+		_, err := service.GetMower(ctx, "1")
+
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("got %v want %v", err, context.Canceled)
+		}
+
+		if elapsed := time.Since(start); elapsed >= repo.Delay {
+			t.Errorf("took %v, the repository call should have been aborted", elapsed)
+		}
+	})
+
+	t.Run("catalog: a deadline aborts the repository call", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := service.GetAvailableMowers(ctx, domain.MowerQuery{})
+
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("got %v want %v", err, context.DeadlineExceeded)
+		}
+	})
+}
+
 func TestCreateMower(t *testing.T) {
 	t.Run("catalog: create new mower", func(t *testing.T) {
 		wantedCatalog := []*domain.Mower{}
@@ -54,11 +94,11 @@ func TestCreateMower(t *testing.T) {
 
 		newMower := domain.CreateMowerDTO{Name: "M-150"}
 
-		insertedMower, err := service.CreateMower(newMower)
+		insertedMower, err := service.CreateMower(context.Background(), newMower)
 
 		lmTesting.AssertNoError(t, err)
 
-		got, _ := service.GetMower(insertedMower.ID)
+		got, _ := service.GetMower(context.Background(), insertedMower.ID)
 
 		if got == nil {
 			t.Errorf("could not find new created mower")
@@ -66,27 +106,63 @@ func TestCreateMower(t *testing.T) {
 	})
 }
 
+func TestMowerTimestamps(t *testing.T) {
+	t.Run("catalog: stamp created, updated and deleted dates from the clock", func(t *testing.T) {
+		clock := lmTesting.NewFakeClock(testNow)
+		repo := &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{}, Clock: clock}
+		service := NewCatalogService(repo)
+
+		created, err := service.CreateMower(context.Background(), domain.CreateMowerDTO{Name: "M-150"})
+		lmTesting.AssertNoError(t, err)
+
+		clock.Advance(time.Hour)
+
+		updated, err := service.UpdateMower(context.Background(), created.ID, domain.UpdateMowerDTO{Name: "M-160"}, 0)
+		lmTesting.AssertNoError(t, err)
+
+		if !time.Time(*updated.UpdatedAt).Equal(testNow.Add(time.Hour)) {
+			t.Errorf("got UpdatedAt %v want %v", updated.UpdatedAt, testNow.Add(time.Hour))
+		}
+
+		clock.Advance(time.Hour)
+
+		deleted, err := service.DeleteMower(context.Background(), created.ID)
+		lmTesting.AssertNoError(t, err)
+
+		lmTesting.AssertMowerEquals(t, *deleted, domain.Mower{
+			ID:        created.ID,
+			Name:      "M-160",
+			CreatedAt: domain.NewTimestamp(testNow),
+			UpdatedAt: domain.NewTimestamp(testNow.Add(2 * time.Hour)),
+			DeletedAt: domain.NewTimestamp(testNow.Add(2 * time.Hour)),
+			Version:   3,
+		})
+	})
+}
+
 func TestUpdateMower(t *testing.T) {
 	t.Run("catalog: update mower", func(t *testing.T) {
 		wantedMower := domain.Mower{ID: "1", Name: "M-90"}
 
 		wantedUpdatedMower := wantedMower
 		wantedUpdatedMower.Name = "M-150"
+		wantedUpdatedMower.UpdatedAt = domain.NewTimestamp(testNow)
+		wantedUpdatedMower.Version = 1
 
 		wantedCatalog := []*domain.Mower{
 			&wantedMower,
 		}
 
-		repo := &lmTesting.StubCatalogRepository{Mowers: wantedCatalog}
+		repo := &lmTesting.StubCatalogRepository{Mowers: wantedCatalog, Clock: lmTesting.NewFakeClock(testNow)}
 		service := NewCatalogService(repo)
 
 		updateMower := domain.UpdateMowerDTO{Name: wantedUpdatedMower.Name}
 
-		_, err := service.UpdateMower(wantedCatalog[0].ID, updateMower)
+		_, err := service.UpdateMower(context.Background(), wantedCatalog[0].ID, updateMower, 0)
 
 		lmTesting.AssertNoError(t, err)
 
-		got, _ := service.GetMower(wantedCatalog[0].ID)
+		got, _ := service.GetMower(context.Background(), wantedCatalog[0].ID)
 
 		if got == nil {
 			t.Errorf("could not find updated mower")
@@ -108,11 +184,11 @@ func TestUpdateMower(t *testing.T) {
 
 		updateMower := domain.UpdateMowerDTO{}
 
-		_, err := service.UpdateMower(wantedCatalog[0].ID, updateMower)
+		_, err := service.UpdateMower(context.Background(), wantedCatalog[0].ID, updateMower, 0)
 
 		lmTesting.AssertNoError(t, err)
 
-		got, _ := service.GetMower(wantedCatalog[0].ID)
+		got, _ := service.GetMower(context.Background(), wantedCatalog[0].ID)
 
 		if got == nil {
 			t.Errorf("could not find updated mower")
@@ -121,6 +197,46 @@ func TestUpdateMower(t *testing.T) {
 
 		lmTesting.AssertMowerEquals(t, wantedMower, *got)
 	})
+
+	t.Run("catalog: return error when updating unknown mower", func(t *testing.T) {
+		service := NewCatalogService(&lmTesting.StubCatalogRepository{})
+
+		_, err := service.UpdateMower(context.Background(), "2", domain.UpdateMowerDTO{Name: "M-90"}, 0)
+
+		if !errors.Is(err, domain.ErrMowerNotFound) || !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("got %v want %v", err, domain.MowerNotFound("2"))
+		}
+	})
+}
+
+func TestUpdateMowerVersion(t *testing.T) {
+	t.Run("catalog: update mower at expected version", func(t *testing.T) {
+		repo := &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{{ID: "1", Name: "M-90", Version: 3}}}
+		service := NewCatalogService(repo)
+
+		got, err := service.UpdateMower(context.Background(), "1", domain.UpdateMowerDTO{Name: "M-150"}, 3)
+
+		lmTesting.AssertNoError(t, err)
+
+		if got.Version != 4 {
+			t.Errorf("got version %d want %d", got.Version, 4)
+		}
+	})
+
+	t.Run("catalog: reject update based on a stale version", func(t *testing.T) {
+		repo := &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{{ID: "1", Name: "M-90", Version: 3}}}
+		service := NewCatalogService(repo)
+
+		_, err := service.UpdateMower(context.Background(), "1", domain.UpdateMowerDTO{Name: "M-150"}, 2)
+
+		if !errors.Is(err, domain.ErrVersionConflict) {
+			t.Fatalf("got %v want %v", err, domain.ErrVersionConflict)
+		}
+
+		got, _ := service.GetMower(context.Background(), "1")
+
+		lmTesting.AssertMowerEquals(t, *got, domain.Mower{ID: "1", Name: "M-90", Version: 3})
+	})
 }
 
 func TestGetAvailableMowers(t *testing.T) {
@@ -140,7 +256,7 @@ func TestGetAvailableMowers(t *testing.T) {
 
 		service := NewCatalogService(repo)
 
-		got, err := service.GetAvailableMowers()
+		got, err := service.GetAvailableMowers(context.Background(), domain.MowerQuery{})
 
 		lmTesting.AssertNoError(t, err)
 
@@ -153,3 +269,187 @@ func TestGetAvailableMowers(t *testing.T) {
 		}
 	})
 }
+
+func TestGetAvailableMowersQuery(t *testing.T) {
+	repo := &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{
+		{ID: "1", Name: "M-90", Specs: domain.MowerSpecs{PowerSource: domain.PowerSourceBattery, CuttingWidthCm: 46}},
+		{ID: "2", Name: "M-150", Specs: domain.MowerSpecs{PowerSource: domain.PowerSourceBattery, CuttingWidthCm: 53}},
+		{ID: "3", Name: "M-480", Specs: domain.MowerSpecs{PowerSource: domain.PowerSourcePetrol, CuttingWidthCm: 53}},
+	}}
+	stores := &lmTesting.StubStoreRepository{Stores: []*domain.Store{{ID: "1", Name: "Lyon"}, {ID: "2", Name: "Paris"}}}
+	inventory := &lmTesting.StubInventoryRepository{Units: []*domain.InventoryUnit{
+		{ID: "1", SerialNumber: "SN-1", ModelID: "1", StoreID: "1"},
+		{ID: "2", SerialNumber: "SN-2", ModelID: "3", StoreID: "1"},
+	}}
+	service := NewCatalogService(repo, WithStoreRepository(stores), WithInventoryRepository(inventory))
+
+	battery, _ := domain.NewMowerFilter("powerSource", domain.OpEq, "battery")
+
+	t.Run("catalog: filter and sort mowers", func(t *testing.T) {
+		got, err := service.GetAvailableMowers(context.Background(), domain.MowerQuery{
+			Filters: []domain.MowerFilter{battery},
+			Sort:    []domain.SortField{{Field: "cuttingWidthCm", Desc: true}},
+		})
+
+		lmTesting.AssertNoError(t, err)
+		lmTesting.AssertCatalogEquals(t, got, []*domain.Mower{repo.Mowers[1], repo.Mowers[0]})
+	})
+
+	t.Run("catalog: keep mowers stocked by the store", func(t *testing.T) {
+		got, err := service.GetAvailableMowers(context.Background(), domain.MowerQuery{Filters: []domain.MowerFilter{battery}, StoreID: "1"})
+
+		lmTesting.AssertNoError(t, err)
+		lmTesting.AssertCatalogEquals(t, got, []*domain.Mower{repo.Mowers[0]})
+
+		got, err = service.GetAvailableMowers(context.Background(), domain.MowerQuery{StoreID: "2"})
+
+		lmTesting.AssertNoError(t, err)
+		lmTesting.AssertCatalogEquals(t, got, []*domain.Mower{})
+	})
+
+	t.Run("catalog: return error when store not found", func(t *testing.T) {
+		_, err := service.GetAvailableMowers(context.Background(), domain.MowerQuery{StoreID: "3"})
+
+		lmTesting.AssertError(t, err, domain.StoreNotFound("3").Error())
+	})
+
+	t.Run("catalog: reject unknown fields and operators", func(t *testing.T) {
+		_, err := domain.NewMowerFilter("price", domain.OpLt, "300")
+
+		if !errors.Is(err, domain.ErrMalformedQuery) {
+			t.Errorf("got %v want %v", err, domain.ErrMalformedQuery)
+		}
+
+		_, err = domain.NewMowerFilter("powerSource", domain.OpGt, "battery")
+
+		var queryErr *domain.QueryError
+
+		if !errors.As(err, &queryErr) || !reflect.DeepEqual(queryErr.Allowed, []string{"eq", "ne"}) {
+			t.Errorf("got %v want the equality operators as allowed", err)
+		}
+	})
+}
+
+func TestGetMowerPage(t *testing.T) {
+	repo := &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{
+		{ID: "1", Name: "M-90"},
+		{ID: "2", Name: "M-150"},
+		{ID: "3", Name: "M-480"},
+	}}
+	service := NewCatalogService(repo)
+
+	t.Run("catalog: page through mowers", func(t *testing.T) {
+		first, err := service.GetMowerPage(context.Background(), domain.PageQuery{Limit: 2})
+
+		lmTesting.AssertNoError(t, err)
+		lmTesting.AssertCatalogEquals(t, first.Mowers, repo.Mowers[:2])
+
+		second, err := service.GetMowerPage(context.Background(), domain.PageQuery{Limit: 2, Cursor: first.Next})
+
+		lmTesting.AssertNoError(t, err)
+		lmTesting.AssertCatalogEquals(t, second.Mowers, repo.Mowers[2:])
+
+		if second.Next != nil || second.Prev == nil {
+			t.Errorf("expected the last page to only link back, got %+v", second)
+		}
+	})
+
+	t.Run("catalog: default to DefaultPageLimit", func(t *testing.T) {
+		page, err := service.GetMowerPage(context.Background(), domain.PageQuery{})
+
+		lmTesting.AssertNoError(t, err)
+
+		if len(page.Mowers) != 3 || page.Next != nil {
+			t.Errorf("expected every mower on a single page, got %+v", page)
+		}
+	})
+
+	t.Run("catalog: reject limits out of bounds", func(t *testing.T) {
+		for _, limit := range []int{-1, domain.MaxPageLimit + 1} {
+			_, err := service.GetMowerPage(context.Background(), domain.PageQuery{Limit: limit})
+
+			if !errors.Is(err, domain.ErrMalformedQuery) {
+				t.Errorf("limit %d: got %v want %v", limit, err, domain.ErrMalformedQuery)
+			}
+		}
+	})
+}
+
+func TestDeleteMower(t *testing.T) {
+	t.Run("catalog: soft delete hides mower from available mowers", func(t *testing.T) {
+		repo := &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{
+			{ID: "1", Name: "M-90"},
+			{ID: "2", Name: "M-150"},
+		}}
+		service := NewCatalogService(repo)
+
+		deleted, err := service.DeleteMower(context.Background(), "1")
+
+		lmTesting.AssertNoError(t, err)
+
+		if deleted.DeletedAt == nil {
+			t.Errorf("expected DeletedAt to be set")
+		}
+
+		available, _ := service.GetAvailableMowers(context.Background(), domain.MowerQuery{})
+
+		lmTesting.AssertCatalogEquals(t, available, []*domain.Mower{{ID: "2", Name: "M-150"}})
+
+		all, _ := service.GetAvailableMowers(context.Background(), domain.MowerQuery{Availability: domain.AnyAvailability})
+
+		if len(all) != 2 {
+			t.Errorf("expected deleted mower when including deleted, got %v", all)
+		}
+	})
+
+	t.Run("catalog: return error when deleting unknown mower", func(t *testing.T) {
+		repo := &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{}}
+		service := NewCatalogService(repo)
+
+		_, err := service.DeleteMower(context.Background(), "2")
+
+		lmTesting.AssertError(t, err, domain.MowerNotFound("2").Error())
+	})
+}
+
+func TestRestoreMower(t *testing.T) {
+	t.Run("catalog: restore a soft deleted mower", func(t *testing.T) {
+		repo := &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{
+			{ID: "1", Name: "M-90"},
+		}, Clock: lmTesting.NewFakeClock(testNow)}
+		service := NewCatalogService(repo)
+
+		_, err := service.DeleteMower(context.Background(), "1")
+		lmTesting.AssertNoError(t, err)
+
+		restored, err := service.RestoreMower(context.Background(), "1")
+		lmTesting.AssertNoError(t, err)
+
+		lmTesting.AssertMowerEquals(t, *restored, domain.Mower{ID: "1", Name: "M-90", UpdatedAt: domain.NewTimestamp(testNow), Version: 2})
+
+		available, _ := service.GetAvailableMowers(context.Background(), domain.MowerQuery{})
+
+		if len(available) != 1 {
+			t.Errorf("expected restored mower to be available, got %v", available)
+		}
+	})
+}
+
+func TestPurgeMower(t *testing.T) {
+	t.Run("catalog: purge removes mower for good", func(t *testing.T) {
+		repo := &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{
+			{ID: "1", Name: "M-90"},
+		}}
+		service := NewCatalogService(repo)
+
+		lmTesting.AssertNoError(t, service.PurgeMower(context.Background(), "1"))
+
+		_, err := service.GetMower(context.Background(), "1")
+
+		lmTesting.AssertError(t, err, domain.MowerNotFound("1").Error())
+
+		err = service.PurgeMower(context.Background(), "1")
+
+		lmTesting.AssertError(t, err, domain.MowerNotFound("1").Error())
+	})
+}
@@ -1,15 +1,22 @@
 package usecase
 
 import (
+	"context"
 	"jrobic/lawn-mower/catalog-service/domain"
 )
 
-func (lm *LMCatalogService) CreateMower(input domain.CreateMowerDTO) (*domain.Mower, error) {
-	mower, err := lm.repo.Add(input)
+func (lm *LMCatalogService) CreateMower(ctx context.Context, input domain.CreateMowerDTO) (*domain.Mower, error) {
+	if err := lm.validator.ValidateCreate(ctx, input); err != nil {
+		return nil, err
+	}
+
+	mower, err := lm.repo.Add(ctx, input)
 
 	if err != nil {
 		return nil, err
 	}
 
+	lm.syncIndex(ctx, mower)
+
 	return mower, nil
 }
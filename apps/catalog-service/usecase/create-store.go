@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"context"
+	"jrobic/lawn-mower/catalog-service/domain"
+)
+
+func (lm *LMCatalogService) CreateStore(ctx context.Context, input domain.CreateStoreDTO) (*domain.Store, error) {
+	if lm.stores == nil {
+		return nil, domain.ErrStoresUnavailable
+	}
+
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	return lm.stores.Add(ctx, input)
+}
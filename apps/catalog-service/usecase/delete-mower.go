@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+	"jrobic/lawn-mower/catalog-service/domain"
+)
+
+// DeleteMower soft deletes a mower: it stays stored with DeletedAt set and is
+// hidden from the available mowers until restored. The repository refuses
+// while units of the mower are in store inventories.
+func (lm *LMCatalogService) DeleteMower(ctx context.Context, id string) (*domain.Mower, error) {
+	mower, err := lm.repo.Delete(ctx, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if mower == nil {
+		return nil, domain.MowerNotFound(id)
+	}
+
+	lm.syncIndex(ctx, mower)
+
+	return mower, nil
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	lmTesting "jrobic/lawn-mower/catalog-service"
+	domain "jrobic/lawn-mower/catalog-service/domain"
+	"testing"
+)
+
+func TestErrorKinds(t *testing.T) {
+	service, _ := newInventoryService()
+	bare := NewCatalogService(&lmTesting.StubCatalogRepository{})
+
+	cases := []struct {
+		name string
+		err  error
+		kind error
+	}{
+		{"unknown mower", second(service.GetMower(context.Background(), "6")), domain.ErrNotFound},
+		{"unknown store", second(service.GetStore(context.Background(), "6")), domain.ErrNotFound},
+		{"unknown unit", second(service.RetireUnit(context.Background(), "1", "6")), domain.ErrNotFound},
+		{"taken serial number", second(service.RegisterUnit(context.Background(), "1", domain.RegisterUnitDTO{SerialNumber: "SN-1", ModelID: "2"})), domain.ErrConflict},
+		{"mower with inventory", second(service.DeleteMower(context.Background(), "1")), domain.ErrConflict},
+		{"stale version", second(service.UpdateMower(context.Background(), "2", domain.UpdateMowerDTO{}, 6)), domain.ErrConflict},
+		{"invalid mower", second(service.CreateMower(context.Background(), domain.CreateMowerDTO{})), domain.ErrValidation},
+		{"missing serial number", second(service.RegisterUnit(context.Background(), "1", domain.RegisterUnitDTO{ModelID: "2"})), domain.ErrValidation},
+		{"stores not configured", second(bare.GetStore(context.Background(), "1")), domain.ErrUnavailable},
+		{"inventory not configured", second(bare.GetModelInventory(context.Background(), "1")), domain.ErrUnavailable},
+	}
+
+	for _, c := range cases {
+		t.Run("catalog: "+c.name, func(t *testing.T) {
+			if !errors.Is(c.err, c.kind) {
+				t.Errorf("got %v want an error matching %v", c.err, c.kind)
+			}
+		})
+	}
+}
+
+func second(_ interface{}, err error) error {
+	return err
+}
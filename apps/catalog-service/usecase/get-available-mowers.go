@@ -1,9 +1,50 @@
 package usecase
 
 import (
+	"context"
 	"jrobic/lawn-mower/catalog-service/domain"
 )
 
-func (lm *LMCatalogService) GetAvailableMowers() ([]*domain.Mower, error) {
-	return lm.repo.FindAvailableMowers()
+// GetAvailableMowers runs the query against the catalog. A query on a store
+// only keeps the models the store has live units of, which needs the store
+// and inventory repositories.
+func (lm *LMCatalogService) GetAvailableMowers(ctx context.Context, query domain.MowerQuery) ([]*domain.Mower, error) {
+	stocked, err := lm.restrictToStore(ctx, &query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !stocked {
+		return []*domain.Mower{}, nil
+	}
+
+	return lm.repo.FindAvailableMowers(ctx, query)
+}
+
+// restrictToStore narrows a query on a store down to the models the store
+// stocks. It reports false when the store stocks none, in which case no
+// mower can match.
+func (lm *LMCatalogService) restrictToStore(ctx context.Context, query *domain.MowerQuery) (bool, error) {
+	if query.StoreID == "" {
+		return true, nil
+	}
+
+	if _, err := lm.GetStore(ctx, query.StoreID); err != nil {
+		return false, err
+	}
+
+	if lm.inventory == nil {
+		return false, domain.ErrInventoryUnavailable
+	}
+
+	modelIDs, err := lm.inventory.FindStockedModelIDs(ctx, query.StoreID)
+
+	if err != nil {
+		return false, err
+	}
+
+	query.IDs = modelIDs
+
+	return len(modelIDs) > 0, nil
 }
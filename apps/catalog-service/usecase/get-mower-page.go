@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"context"
+	"jrobic/lawn-mower/catalog-service/domain"
+)
+
+// GetMowerPage returns one page of the mowers matching the query,
+// DefaultPageLimit mowers when no limit is given. Queries on a store work
+// like with GetAvailableMowers.
+func (lm *LMCatalogService) GetMowerPage(ctx context.Context, query domain.PageQuery) (*domain.MowerPage, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	stocked, err := lm.restrictToStore(ctx, &query.MowerQuery)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !stocked {
+		return domain.PageMowers(nil, query), nil
+	}
+
+	return lm.repo.FindMowerPage(ctx, query)
+}
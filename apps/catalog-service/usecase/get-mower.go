@@ -1,15 +1,19 @@
 package usecase
 
 import (
-	"fmt"
+	"context"
 	"jrobic/lawn-mower/catalog-service/domain"
 )
 
-func (lm *LMCatalogService) GetMower(id string) (*domain.Mower, error) {
-	mower, _ := lm.repo.Find(id)
+func (lm *LMCatalogService) GetMower(ctx context.Context, id string) (*domain.Mower, error) {
+	mower, err := lm.repo.Find(ctx, id)
+
+	if err != nil {
+		return nil, err
+	}
 
 	if mower == nil {
-		return nil, fmt.Errorf(domain.ErrMowerNotFound, id)
+		return nil, domain.MowerNotFound(id)
 	}
 
 	return mower, nil
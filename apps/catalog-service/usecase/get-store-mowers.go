@@ -0,0 +1,12 @@
+package usecase
+
+import (
+	"context"
+	"jrobic/lawn-mower/catalog-service/domain"
+)
+
+// GetStoreMowers returns the mower models the store currently stocks,
+// leaving out models that have been deleted from the catalog.
+func (lm *LMCatalogService) GetStoreMowers(ctx context.Context, storeID string) ([]*domain.Mower, error) {
+	return lm.GetAvailableMowers(ctx, domain.MowerQuery{StoreID: storeID})
+}
@@ -0,0 +1,24 @@
+package usecase
+
+import (
+	"context"
+	"jrobic/lawn-mower/catalog-service/domain"
+)
+
+func (lm *LMCatalogService) GetStore(ctx context.Context, id string) (*domain.Store, error) {
+	if lm.stores == nil {
+		return nil, domain.ErrStoresUnavailable
+	}
+
+	store, err := lm.stores.Find(ctx, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if store == nil {
+		return nil, domain.StoreNotFound(id)
+	}
+
+	return store, nil
+}
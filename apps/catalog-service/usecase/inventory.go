@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"strings"
+)
+
+// RegisterUnit adds a physical unit of an available mower model to the store.
+func (lm *LMCatalogService) RegisterUnit(ctx context.Context, storeID string, input domain.RegisterUnitDTO) (*domain.InventoryUnit, error) {
+	if lm.inventory == nil {
+		return nil, domain.ErrInventoryUnavailable
+	}
+
+	if _, err := lm.GetStore(ctx, storeID); err != nil {
+		return nil, err
+	}
+
+	input.SerialNumber = strings.TrimSpace(input.SerialNumber)
+
+	if input.SerialNumber == "" {
+		return nil, domain.ErrSerialNumberRequired
+	}
+
+	mower, err := lm.GetMower(ctx, input.ModelID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if mower.DeletedAt != nil {
+		return nil, domain.MowerNotFound(input.ModelID)
+	}
+
+	input.StoreID = storeID
+
+	return lm.inventory.Add(ctx, input)
+}
+
+// RetireUnit takes a unit of the store out of inventory. Its serial number
+// stays reserved.
+func (lm *LMCatalogService) RetireUnit(ctx context.Context, storeID string, unitID string) (*domain.InventoryUnit, error) {
+	if _, err := lm.findStoreUnit(ctx, storeID, unitID); err != nil {
+		return nil, err
+	}
+
+	unit, err := lm.inventory.Retire(ctx, unitID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if unit == nil {
+		return nil, domain.UnitNotFound(unitID)
+	}
+
+	return unit, nil
+}
+
+// MoveUnit transfers a unit of the store to another store.
+func (lm *LMCatalogService) MoveUnit(ctx context.Context, storeID string, unitID string, toStoreID string) (*domain.InventoryUnit, error) {
+	if _, err := lm.findStoreUnit(ctx, storeID, unitID); err != nil {
+		return nil, err
+	}
+
+	if _, err := lm.GetStore(ctx, toStoreID); err != nil {
+		return nil, err
+	}
+
+	unit, err := lm.inventory.Move(ctx, unitID, toStoreID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if unit == nil {
+		return nil, domain.UnitNotFound(unitID)
+	}
+
+	return unit, nil
+}
+
+func (lm *LMCatalogService) GetStoreInventory(ctx context.Context, storeID string) ([]*domain.InventoryUnit, error) {
+	if lm.inventory == nil {
+		return nil, domain.ErrInventoryUnavailable
+	}
+
+	if _, err := lm.GetStore(ctx, storeID); err != nil {
+		return nil, err
+	}
+
+	return lm.inventory.FindByStore(ctx, storeID)
+}
+
+func (lm *LMCatalogService) GetModelInventory(ctx context.Context, modelID string) ([]*domain.InventoryUnit, error) {
+	if lm.inventory == nil {
+		return nil, domain.ErrInventoryUnavailable
+	}
+
+	if _, err := lm.GetMower(ctx, modelID); err != nil {
+		return nil, err
+	}
+
+	return lm.inventory.FindByModel(ctx, modelID)
+}
+
+// findStoreUnit returns the live unit, reporting it as not found when it is
+// retired or held by another store.
+func (lm *LMCatalogService) findStoreUnit(ctx context.Context, storeID string, unitID string) (*domain.InventoryUnit, error) {
+	if lm.inventory == nil {
+		return nil, domain.ErrInventoryUnavailable
+	}
+
+	if _, err := lm.GetStore(ctx, storeID); err != nil {
+		return nil, err
+	}
+
+	unit, err := lm.inventory.Find(ctx, unitID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if unit == nil || unit.StoreID != storeID || unit.DeletedAt != nil {
+		return nil, domain.UnitNotFound(unitID)
+	}
+
+	return unit, nil
+}
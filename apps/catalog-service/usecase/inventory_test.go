@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	lmTesting "jrobic/lawn-mower/catalog-service"
+	domain "jrobic/lawn-mower/catalog-service/domain"
+	"testing"
+	"time"
+)
+
+func newInventoryService() (*LMCatalogService, *lmTesting.StubInventoryRepository) {
+	repo := &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{
+		{ID: "1", Name: "M-90"},
+		{ID: "2", Name: "M-150"},
+		{ID: "3", Name: "M-480", DeletedAt: domain.NewTimestamp(time.Now())},
+	}}
+	stores := &lmTesting.StubStoreRepository{Stores: []*domain.Store{
+		{ID: "1", Name: "Lyon"},
+		{ID: "2", Name: "Paris"},
+	}}
+	inventory := &lmTesting.StubInventoryRepository{Units: []*domain.InventoryUnit{
+		{ID: "1", SerialNumber: "SN-1", ModelID: "1", StoreID: "1"},
+	}}
+	repo.Inventory = inventory
+
+	return NewCatalogService(repo, WithStoreRepository(stores), WithInventoryRepository(inventory)), inventory
+}
+
+func TestRegisterUnit(t *testing.T) {
+	t.Run("catalog: register unit in store", func(t *testing.T) {
+		service, _ := newInventoryService()
+
+		got, err := service.RegisterUnit(context.Background(), "1", domain.RegisterUnitDTO{SerialNumber: " SN-2 ", ModelID: "2", StoreID: "2"})
+
+		lmTesting.AssertNoError(t, err)
+
+		if *got != (domain.InventoryUnit{ID: "2", SerialNumber: "SN-2", ModelID: "2", StoreID: "1"}) {
+			t.Errorf("got %v want unit SN-2 of model #2 at store #1", got)
+		}
+	})
+
+	t.Run("catalog: reject taken serial number", func(t *testing.T) {
+		service, _ := newInventoryService()
+
+		_, err := service.RegisterUnit(context.Background(), "1", domain.RegisterUnitDTO{SerialNumber: "SN-1", ModelID: "2"})
+
+		if !errors.Is(err, domain.ErrSerialNumberTaken) {
+			t.Errorf("got %v want %v", err, domain.ErrSerialNumberTaken)
+		}
+	})
+
+	t.Run("catalog: reject missing serial number", func(t *testing.T) {
+		service, _ := newInventoryService()
+
+		_, err := service.RegisterUnit(context.Background(), "1", domain.RegisterUnitDTO{SerialNumber: " ", ModelID: "2"})
+
+		if !errors.Is(err, domain.ErrSerialNumberRequired) {
+			t.Errorf("got %v want %v", err, domain.ErrSerialNumberRequired)
+		}
+	})
+
+	t.Run("catalog: reject unknown or deleted model", func(t *testing.T) {
+		service, _ := newInventoryService()
+
+		for _, modelID := range []string{"3", "6"} {
+			_, err := service.RegisterUnit(context.Background(), "1", domain.RegisterUnitDTO{SerialNumber: "SN-2", ModelID: modelID})
+
+			lmTesting.AssertError(t, err, domain.MowerNotFound(modelID).Error())
+		}
+	})
+
+	t.Run("catalog: inventory use cases need an inventory repository", func(t *testing.T) {
+		service := NewCatalogService(&lmTesting.StubCatalogRepository{}, WithStoreRepository(&lmTesting.StubStoreRepository{}))
+
+		_, err := service.GetStoreInventory(context.Background(), "1")
+
+		if !errors.Is(err, domain.ErrInventoryUnavailable) {
+			t.Errorf("got %v want %v", err, domain.ErrInventoryUnavailable)
+		}
+	})
+}
+
+func TestMoveAndRetireUnit(t *testing.T) {
+	t.Run("catalog: move unit between stores", func(t *testing.T) {
+		service, _ := newInventoryService()
+
+		_, err := service.MoveUnit(context.Background(), "1", "1", "2")
+		lmTesting.AssertNoError(t, err)
+
+		lyon, _ := service.GetStoreInventory(context.Background(), "1")
+		paris, _ := service.GetStoreInventory(context.Background(), "2")
+
+		if len(lyon) != 0 || len(paris) != 1 {
+			t.Errorf("got %v and %v want the unit at Paris only", lyon, paris)
+		}
+	})
+
+	t.Run("catalog: unit must belong to the store", func(t *testing.T) {
+		service, _ := newInventoryService()
+
+		_, err := service.RetireUnit(context.Background(), "2", "1")
+
+		lmTesting.AssertError(t, err, domain.UnitNotFound("1").Error())
+	})
+
+	t.Run("catalog: retired unit leaves the model inventory", func(t *testing.T) {
+		service, _ := newInventoryService()
+
+		retired, err := service.RetireUnit(context.Background(), "1", "1")
+		lmTesting.AssertNoError(t, err)
+
+		if retired.DeletedAt == nil {
+			t.Errorf("expected DeletedAt to be set")
+		}
+
+		units, err := service.GetModelInventory(context.Background(), "1")
+		lmTesting.AssertNoError(t, err)
+
+		if len(units) != 0 {
+			t.Errorf("got %v want no unit", units)
+		}
+
+		_, err = service.RetireUnit(context.Background(), "1", "1")
+
+		lmTesting.AssertError(t, err, domain.UnitNotFound("1").Error())
+	})
+}
+
+func TestDeleteMowerWithInventory(t *testing.T) {
+	t.Run("catalog: reject deleting or purging a model with live units", func(t *testing.T) {
+		service, _ := newInventoryService()
+
+		_, err := service.DeleteMower(context.Background(), "1")
+
+		if !errors.Is(err, domain.ErrMowerHasInventory) {
+			t.Errorf("got %v want %v", err, domain.ErrMowerHasInventory)
+		}
+
+		err = service.PurgeMower(context.Background(), "1")
+
+		if !errors.Is(err, domain.ErrMowerHasInventory) {
+			t.Errorf("got %v want %v", err, domain.ErrMowerHasInventory)
+		}
+	})
+
+	t.Run("catalog: delete model once its units are retired", func(t *testing.T) {
+		service, _ := newInventoryService()
+
+		_, err := service.RetireUnit(context.Background(), "1", "1")
+		lmTesting.AssertNoError(t, err)
+
+		_, err = service.DeleteMower(context.Background(), "1")
+		lmTesting.AssertNoError(t, err)
+	})
+}
@@ -0,0 +1,24 @@
+package usecase
+
+import (
+	"context"
+	"jrobic/lawn-mower/catalog-service/domain"
+)
+
+// PurgeMower removes a mower for good, whether or not it was soft deleted,
+// unless units of it are in store inventories.
+func (lm *LMCatalogService) PurgeMower(ctx context.Context, id string) error {
+	mower, err := lm.repo.Purge(ctx, id)
+
+	if err != nil {
+		return err
+	}
+
+	if mower == nil {
+		return domain.MowerNotFound(id)
+	}
+
+	lm.dropFromIndex(ctx, mower)
+
+	return nil
+}
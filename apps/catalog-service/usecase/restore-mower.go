@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+	"jrobic/lawn-mower/catalog-service/domain"
+)
+
+func (lm *LMCatalogService) RestoreMower(ctx context.Context, id string) (*domain.Mower, error) {
+	mower, err := lm.repo.Restore(ctx, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if mower == nil {
+		return nil, domain.MowerNotFound(id)
+	}
+
+	lm.syncIndex(ctx, mower)
+
+	return mower, nil
+}
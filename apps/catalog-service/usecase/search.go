@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"log"
+	"sync"
+	"time"
+)
+
+// SearchMowers finds the live mowers matching every word of text, best
+// matches first. limit defaults to domain.DefaultSearchLimit.
+func (lm *LMCatalogService) SearchMowers(ctx context.Context, text string, limit int) ([]domain.SearchHit, error) {
+	if lm.index == nil {
+		return nil, domain.ErrSearchUnavailable
+	}
+
+	limit, err := domain.ValidateSearch("q", text, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return lm.index.Search(ctx, text, limit)
+}
+
+// SuggestMowers completes prefix into the names of live mowers.
+func (lm *LMCatalogService) SuggestMowers(ctx context.Context, prefix string, limit int) ([]string, error) {
+	if lm.index == nil {
+		return nil, domain.ErrSearchUnavailable
+	}
+
+	limit, err := domain.ValidateSearch("prefix", prefix, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return lm.index.Suggest(ctx, prefix, limit)
+}
+
+// syncIndex keeps the search index in step with a mower that has just been
+// written: live mowers are (re)indexed, deleted ones removed. The change is
+// applied by then, so an index failure does not fail it: it is logged and
+// the mower reindexed later, see reindexer.
+func (lm *LMCatalogService) syncIndex(ctx context.Context, mower *domain.Mower) {
+	lm.updateIndex(ctx, mower.ID, mower.Version, mower)
+}
+
+// dropFromIndex removes a purged mower from the search index, like syncIndex.
+func (lm *LMCatalogService) dropFromIndex(ctx context.Context, mower *domain.Mower) {
+	lm.updateIndex(ctx, mower.ID, mower.Version, nil)
+}
+
+func (lm *LMCatalogService) updateIndex(ctx context.Context, id string, version int64, mower *domain.Mower) {
+	if lm.index == nil {
+		return
+	}
+
+	if err := lm.writeIndex(ctx, id, version, mower); err != nil {
+		log.Printf("search index: mower %s at version %d not updated, retrying: %v", id, version, err)
+
+		if lm.reindex.add(id, version) {
+			go lm.retryIndex()
+		}
+	}
+}
+
+// writeIndex indexes mower, or removes id at version when mower is gone or
+// deleted.
+func (lm *LMCatalogService) writeIndex(ctx context.Context, id string, version int64, mower *domain.Mower) error {
+	if mower == nil || mower.DeletedAt != nil {
+		return lm.index.Remove(ctx, id, version)
+	}
+
+	return lm.index.Index(ctx, mower)
+}
+
+const (
+	// reindexDelay is how long a failed index update waits to be retried,
+	// doubling up to maxReindexDelay while the index keeps failing.
+	reindexDelay    = time.Second
+	maxReindexDelay = time.Minute
+	// reindexTimeout bounds each retry, which no request waits for.
+	reindexTimeout = 5 * time.Second
+)
+
+// reindexer holds the mowers whose index update failed, with the version
+// the update was for. A single goroutine retries them with the state they
+// have by then, which the versioned index never lets go backwards.
+type reindexer struct {
+	lock    sync.Mutex
+	pending map[string]int64
+	running bool
+	delay   time.Duration
+}
+
+// add records a failed update, keeping the latest version of the mower. It
+// reports whether no goroutine is retrying yet, the caller then starting one.
+func (r *reindexer) add(id string, version int64) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if version > r.pending[id] {
+		r.pending[id] = version
+	}
+
+	start := !r.running
+	r.running = true
+
+	return start
+}
+
+// take hands out the pending updates, or reports that there are none left,
+// the retrying goroutine then stopping.
+func (r *reindexer) take() (map[string]int64, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if len(r.pending) == 0 {
+		r.running = false
+		return nil, false
+	}
+
+	pending := r.pending
+	r.pending = map[string]int64{}
+
+	return pending, true
+}
+
+// retryIndex brings the index up to date with the pending mowers, waiting
+// longer after every round that still fails, until none is left.
+func (lm *LMCatalogService) retryIndex() {
+	for delay := lm.reindex.delay; ; delay *= 2 {
+		if delay > maxReindexDelay {
+			delay = maxReindexDelay
+		}
+
+		time.Sleep(delay)
+
+		pending, ok := lm.reindex.take()
+
+		if !ok {
+			return
+		}
+
+		for id, version := range pending {
+			if err := lm.reindexMower(id, version); err != nil {
+				log.Printf("search index: mower %s still out of date, retrying: %v", id, err)
+				lm.reindex.add(id, version)
+			}
+		}
+	}
+}
+
+// reindexMower writes the current state of the mower to the index, its
+// removal at version when it was purged.
+func (lm *LMCatalogService) reindexMower(id string, version int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), reindexTimeout)
+	defer cancel()
+
+	mower, err := lm.repo.Find(ctx, id)
+
+	if err != nil {
+		return err
+	}
+
+	if mower != nil {
+		version = mower.Version
+	}
+
+	return lm.writeIndex(ctx, id, version, mower)
+}
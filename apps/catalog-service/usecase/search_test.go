@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	lmTesting "jrobic/lawn-mower/catalog-service"
+	"jrobic/lawn-mower/catalog-service/domain"
+	"testing"
+	"time"
+)
+
+func TestSearchIndexSync(t *testing.T) {
+	index := &lmTesting.StubSearchIndex{}
+	service := NewCatalogService(&lmTesting.StubCatalogRepository{}, WithSearchIndex(index))
+
+	assertIndexed := func(t *testing.T, want ...string) {
+		t.Helper()
+
+		if len(index.Mowers) != len(want) {
+			t.Fatalf("got %v indexed want %v", index.Mowers, want)
+		}
+
+		for _, name := range want {
+			if names, _ := index.Suggest(context.Background(), name, 1); len(names) != 1 {
+				t.Errorf("expected %s to be indexed, got %v", name, index.Mowers)
+			}
+		}
+	}
+
+	created, err := service.CreateMower(context.Background(), domain.CreateMowerDTO{Name: "M-90"})
+	lmTesting.AssertNoError(t, err)
+
+	t.Run("catalog: index created mowers", func(t *testing.T) {
+		assertIndexed(t, "M-90")
+	})
+
+	t.Run("catalog: reindex updated mowers", func(t *testing.T) {
+		_, err := service.UpdateMower(context.Background(), created.ID, domain.UpdateMowerDTO{Name: "M-95"}, 0)
+		lmTesting.AssertNoError(t, err)
+
+		assertIndexed(t, "M-95")
+	})
+
+	t.Run("catalog: remove deleted mowers until restored", func(t *testing.T) {
+		_, err := service.DeleteMower(context.Background(), created.ID)
+		lmTesting.AssertNoError(t, err)
+
+		assertIndexed(t)
+
+		_, err = service.RestoreMower(context.Background(), created.ID)
+		lmTesting.AssertNoError(t, err)
+
+		assertIndexed(t, "M-95")
+	})
+
+	t.Run("catalog: remove purged mowers", func(t *testing.T) {
+		lmTesting.AssertNoError(t, service.PurgeMower(context.Background(), created.ID))
+
+		assertIndexed(t)
+	})
+}
+
+func TestSearchIndexRetry(t *testing.T) {
+	index := &lmTesting.StubSearchIndex{}
+	service := NewCatalogService(&lmTesting.StubCatalogRepository{}, WithSearchIndex(index))
+	service.reindex.delay = time.Millisecond
+
+	suggested := func(name string) func() bool {
+		return func() bool {
+			names, _ := index.Suggest(context.Background(), name, 1)
+			return len(names) == 1
+		}
+	}
+
+	eventually := func(t *testing.T, what string, done func() bool) {
+		t.Helper()
+
+		for deadline := time.Now().Add(5 * time.Second); !done(); time.Sleep(time.Millisecond) {
+			if time.Now().After(deadline) {
+				t.Fatalf("%s never happened", what)
+			}
+		}
+	}
+
+	index.Failures = 3
+
+	created, err := service.CreateMower(context.Background(), domain.CreateMowerDTO{Name: "M-90"})
+	lmTesting.AssertNoError(t, err)
+
+	t.Run("catalog: apply changes the index failed and index them later", func(t *testing.T) {
+		eventually(t, "indexing M-90", suggested("M-90"))
+	})
+
+	t.Run("catalog: remove purged mowers once the index is back", func(t *testing.T) {
+		index.Failures = 1
+
+		lmTesting.AssertNoError(t, service.PurgeMower(context.Background(), created.ID))
+
+		eventually(t, "removing M-90", func() bool { return !suggested("M-90")() })
+	})
+}
+
+func TestSearchMowers(t *testing.T) {
+	index := &lmTesting.StubSearchIndex{}
+	service := NewCatalogService(&lmTesting.StubCatalogRepository{}, WithSearchIndex(index))
+
+	for _, name := range []string{"M-90", "M-480"} {
+		_, err := service.CreateMower(context.Background(), domain.CreateMowerDTO{Name: name})
+		lmTesting.AssertNoError(t, err)
+	}
+
+	t.Run("catalog: search and suggest mowers", func(t *testing.T) {
+		hits, err := service.SearchMowers(context.Background(), "480", 0)
+		lmTesting.AssertNoError(t, err)
+
+		if len(hits) != 1 || hits[0].Mower.Name != "M-480" {
+			t.Errorf("got %v want M-480", hits)
+		}
+
+		names, err := service.SuggestMowers(context.Background(), "m-4", 0)
+		lmTesting.AssertNoError(t, err)
+
+		if len(names) != 1 || names[0] != "M-480" {
+			t.Errorf("got %v want M-480", names)
+		}
+	})
+
+	t.Run("catalog: reject blank texts and limits out of bounds", func(t *testing.T) {
+		_, err := service.SearchMowers(context.Background(), "  ", 0)
+
+		if !errors.Is(err, domain.ErrMalformedQuery) {
+			t.Errorf("got %v want %v", err, domain.ErrMalformedQuery)
+		}
+
+		_, err = service.SuggestMowers(context.Background(), "M", domain.MaxSearchLimit+1)
+
+		if !errors.Is(err, domain.ErrMalformedQuery) {
+			t.Errorf("got %v want %v", err, domain.ErrMalformedQuery)
+		}
+	})
+
+	t.Run("catalog: search needs an index", func(t *testing.T) {
+		_, err := NewCatalogService(&lmTesting.StubCatalogRepository{}).SearchMowers(context.Background(), "M", 0)
+
+		if !errors.Is(err, domain.ErrSearchUnavailable) {
+			t.Errorf("got %v want %v", err, domain.ErrSearchUnavailable)
+		}
+	})
+}
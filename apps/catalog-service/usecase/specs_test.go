@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	lmTesting "jrobic/lawn-mower/catalog-service"
+	domain "jrobic/lawn-mower/catalog-service/domain"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testSpecs = domain.MowerSpecs{
+	PowerSource:        domain.PowerSourceBattery,
+	CuttingWidthCm:     46,
+	CuttingHeightMinMm: 25,
+	CuttingHeightMaxMm: 75,
+	GrassBagLiters:     55,
+	WeightKg:           26.5,
+	SelfPropelled:      true,
+	RecommendedAreaM2:  800,
+	Description:        "Quiet battery mower for medium lawns",
+}
+
+func TestMowerSpecs(t *testing.T) {
+	t.Run("catalog: create mower with specs", func(t *testing.T) {
+		service := NewCatalogService(&lmTesting.StubCatalogRepository{})
+
+		got, err := service.CreateMower(context.Background(), domain.CreateMowerDTO{Name: "M-150", Specs: testSpecs})
+
+		lmTesting.AssertNoError(t, err)
+
+		if got.Specs != testSpecs {
+			t.Errorf("got %+v want %+v", got.Specs, testSpecs)
+		}
+	})
+
+	t.Run("catalog: reject invalid specs listing every field", func(t *testing.T) {
+		service := NewCatalogService(&lmTesting.StubCatalogRepository{})
+
+		_, err := service.CreateMower(context.Background(), domain.CreateMowerDTO{Name: "M-150", Specs: domain.MowerSpecs{
+			PowerSource:        "nuclear",
+			CuttingWidthCm:     5,
+			CuttingHeightMinMm: 80,
+			CuttingHeightMaxMm: 30,
+			WeightKg:           -1,
+			Description:        strings.Repeat("a", domain.MaxDescriptionLength+1),
+		}})
+
+		var validationErr *domain.ValidationError
+
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("got %v want a validation error", err)
+		}
+
+		fields := []string{}
+
+		for _, e := range validationErr.Fields {
+			fields = append(fields, e.Field)
+		}
+
+		want := []string{
+			"specs.powerSource",
+			"specs.cuttingWidthCm",
+			"specs.cuttingHeightMaxMm",
+			"specs.weightKg",
+			"specs.description",
+		}
+
+		if !reflect.DeepEqual(fields, want) {
+			t.Errorf("got %v want %v", fields, want)
+		}
+	})
+
+	t.Run("catalog: update only the given specs", func(t *testing.T) {
+		repo := &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{{ID: "1", Name: "M-150", Specs: testSpecs}}}
+		service := NewCatalogService(repo)
+
+		width, selfPropelled := 53, false
+
+		got, err := service.UpdateMower(context.Background(), "1", domain.UpdateMowerDTO{Specs: &domain.MowerSpecsPatch{
+			CuttingWidthCm: &width,
+			SelfPropelled:  &selfPropelled,
+		}}, 0)
+
+		lmTesting.AssertNoError(t, err)
+
+		want := testSpecs
+		want.CuttingWidthCm = 53
+		want.SelfPropelled = false
+
+		if got.Specs != want {
+			t.Errorf("got %+v want %+v", got.Specs, want)
+		}
+	})
+
+	t.Run("catalog: validate specs once merged", func(t *testing.T) {
+		repo := &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{{ID: "1", Name: "M-150", Specs: testSpecs}}}
+		service := NewCatalogService(repo)
+
+		minHeight := 90
+
+		_, err := service.UpdateMower(context.Background(), "1", domain.UpdateMowerDTO{Specs: &domain.MowerSpecsPatch{
+			CuttingHeightMinMm: &minHeight,
+		}}, 0)
+
+		lmTesting.AssertError(t, err, "[Catalog] Invalid mower: specs.cuttingHeightMaxMm must not be lower than specs.cuttingHeightMinMm")
+
+		if repo.Mowers[0].Specs != testSpecs {
+			t.Errorf("expected specs to be left unchanged, got %+v", repo.Mowers[0].Specs)
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	lmTesting "jrobic/lawn-mower/catalog-service"
+	domain "jrobic/lawn-mower/catalog-service/domain"
+	"testing"
+	"time"
+)
+
+func TestCreateStore(t *testing.T) {
+	t.Run("catalog: create new store", func(t *testing.T) {
+		stores := &lmTesting.StubStoreRepository{}
+		service := NewCatalogService(&lmTesting.StubCatalogRepository{}, WithStoreRepository(stores))
+
+		created, err := service.CreateStore(context.Background(), domain.CreateStoreDTO{Name: "Lyon"})
+
+		lmTesting.AssertNoError(t, err)
+
+		got, err := service.GetStore(context.Background(), created.ID)
+
+		lmTesting.AssertNoError(t, err)
+
+		if got.Name != "Lyon" {
+			t.Errorf("got %v want store Lyon", got)
+		}
+	})
+
+	t.Run("catalog: store use cases need a store repository", func(t *testing.T) {
+		service := NewCatalogService(&lmTesting.StubCatalogRepository{})
+
+		_, err := service.CreateStore(context.Background(), domain.CreateStoreDTO{Name: "Lyon"})
+
+		if !errors.Is(err, domain.ErrStoresUnavailable) {
+			t.Errorf("got %v want %v", err, domain.ErrStoresUnavailable)
+		}
+	})
+}
+
+func TestUpdateStore(t *testing.T) {
+	t.Run("catalog: update store", func(t *testing.T) {
+		stores := &lmTesting.StubStoreRepository{Stores: []*domain.Store{{ID: "1", Name: "Lyon"}}}
+		service := NewCatalogService(&lmTesting.StubCatalogRepository{}, WithStoreRepository(stores))
+
+		got, err := service.UpdateStore(context.Background(), "1", domain.UpdateStoreDTO{Name: "Lyon Part-Dieu"})
+
+		lmTesting.AssertNoError(t, err)
+
+		if got.Name != "Lyon Part-Dieu" {
+			t.Errorf("got %v want store Lyon Part-Dieu", got)
+		}
+	})
+
+	t.Run("catalog: return error when store not found", func(t *testing.T) {
+		stores := &lmTesting.StubStoreRepository{}
+		service := NewCatalogService(&lmTesting.StubCatalogRepository{}, WithStoreRepository(stores))
+
+		_, err := service.UpdateStore(context.Background(), "2", domain.UpdateStoreDTO{Name: "Paris"})
+
+		lmTesting.AssertError(t, err, domain.StoreNotFound("2").Error())
+	})
+}
+
+func TestGetStoreMowers(t *testing.T) {
+	deletedAt := domain.NewTimestamp(time.Now())
+
+	repo := &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{
+		{ID: "1", Name: "M-90"},
+		{ID: "2", Name: "M-150"},
+		{ID: "3", Name: "M-480", DeletedAt: deletedAt},
+	}}
+	stores := &lmTesting.StubStoreRepository{Stores: []*domain.Store{{ID: "1", Name: "Lyon"}}}
+	inventory := &lmTesting.StubInventoryRepository{Units: []*domain.InventoryUnit{
+		{ID: "1", SerialNumber: "SN-1", ModelID: "2", StoreID: "1"},
+		{ID: "2", SerialNumber: "SN-2", ModelID: "2", StoreID: "1"},
+		{ID: "3", SerialNumber: "SN-3", ModelID: "3", StoreID: "1"},
+	}}
+	service := NewCatalogService(repo, WithStoreRepository(stores), WithInventoryRepository(inventory))
+
+	t.Run("catalog: return models stocked by the store", func(t *testing.T) {
+		got, err := service.GetStoreMowers(context.Background(), "1")
+
+		lmTesting.AssertNoError(t, err)
+		lmTesting.AssertCatalogEquals(t, got, []*domain.Mower{{ID: "2", Name: "M-150"}})
+	})
+
+	t.Run("catalog: return error when store not found", func(t *testing.T) {
+		_, err := service.GetStoreMowers(context.Background(), "2")
+
+		lmTesting.AssertError(t, err, domain.StoreNotFound("2").Error())
+	})
+}
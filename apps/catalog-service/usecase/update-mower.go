@@ -1,15 +1,40 @@
 package usecase
 
 import (
+	"context"
 	"jrobic/lawn-mower/catalog-service/domain"
 )
 
-func (lm *LMCatalogService) UpdateMower(id string, input domain.UpdateMowerDTO) (*domain.Mower, error) {
-	mower, err := lm.repo.Patch(id, input)
+// UpdateMower applies input to the mower, provided it is still at
+// expectedVersion. An expectedVersion of 0 updates whatever the version.
+// Only the given fields are validated, specs once merged with the current
+// ones.
+func (lm *LMCatalogService) UpdateMower(ctx context.Context, id string, input domain.UpdateMowerDTO, expectedVersion int64) (*domain.Mower, error) {
+	current, err := lm.repo.Find(ctx, id)
 
 	if err != nil {
 		return nil, err
 	}
 
+	if current == nil {
+		return nil, domain.MowerNotFound(id)
+	}
+
+	if err := lm.validator.ValidateUpdate(ctx, *current, input); err != nil {
+		return nil, err
+	}
+
+	mower, err := lm.repo.Patch(ctx, id, input, expectedVersion)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if mower == nil {
+		return nil, domain.MowerNotFound(id)
+	}
+
+	lm.syncIndex(ctx, mower)
+
 	return mower, nil
 }
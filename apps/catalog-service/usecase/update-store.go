@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"context"
+	"jrobic/lawn-mower/catalog-service/domain"
+)
+
+func (lm *LMCatalogService) UpdateStore(ctx context.Context, id string, input domain.UpdateStoreDTO) (*domain.Store, error) {
+	if lm.stores == nil {
+		return nil, domain.ErrStoresUnavailable
+	}
+
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	store, err := lm.stores.Patch(ctx, id, input)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if store == nil {
+		return nil, domain.StoreNotFound(id)
+	}
+
+	return store, nil
+}
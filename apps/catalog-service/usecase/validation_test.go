@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	lmTesting "jrobic/lawn-mower/catalog-service"
+	domain "jrobic/lawn-mower/catalog-service/domain"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func assertInvalidFields(t testing.TB, err error, want ...domain.FieldError) {
+	t.Helper()
+
+	var validationErr *domain.ValidationError
+
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("got %v want a validation error", err)
+	}
+
+	if !reflect.DeepEqual(validationErr.Fields, want) {
+		t.Errorf("got %+v want %+v", validationErr.Fields, want)
+	}
+}
+
+func TestValidateMower(t *testing.T) {
+	newService := func() (*LMCatalogService, *lmTesting.StubCatalogRepository) {
+		repo := &lmTesting.StubCatalogRepository{Mowers: []*domain.Mower{
+			{ID: "1", Name: "M-90"},
+			{ID: "2", Name: "M-150"},
+		}}
+
+		return NewCatalogService(repo), repo
+	}
+
+	t.Run("catalog: name is required", func(t *testing.T) {
+		service, repo := newService()
+
+		_, err := service.CreateMower(context.Background(), domain.CreateMowerDTO{Name: "  "})
+
+		assertInvalidFields(t, err, domain.FieldError{Field: "name", Message: "is required"})
+
+		if len(repo.Mowers) != 2 {
+			t.Errorf("expected invalid mower not to be stored, got %v", repo.Mowers)
+		}
+	})
+
+	t.Run("catalog: name length is limited", func(t *testing.T) {
+		service, _ := newService()
+
+		_, err := service.CreateMower(context.Background(), domain.CreateMowerDTO{Name: strings.Repeat("M", domain.MaxNameLength+1)})
+
+		assertInvalidFields(t, err, domain.FieldError{Field: "name", Message: "must not exceed 100 characters"})
+	})
+
+	t.Run("catalog: name is unique regardless of case", func(t *testing.T) {
+		service, _ := newService()
+
+		_, err := service.CreateMower(context.Background(), domain.CreateMowerDTO{Name: "m-90"})
+
+		assertInvalidFields(t, err, domain.FieldError{Field: "name", Message: "is already used by another mower"})
+	})
+
+	t.Run("catalog: every invalid field is listed", func(t *testing.T) {
+		service, _ := newService()
+
+		_, err := service.CreateMower(context.Background(), domain.CreateMowerDTO{Specs: domain.MowerSpecs{GrassBagLiters: -5}})
+
+		assertInvalidFields(t, err,
+			domain.FieldError{Field: "name", Message: "is required"},
+			domain.FieldError{Field: "specs.grassBagLiters", Message: "must be between 0 and 150"},
+		)
+	})
+
+	t.Run("catalog: update validates only the given fields", func(t *testing.T) {
+		service, _ := newService()
+
+		width := 10
+
+		_, err := service.UpdateMower(context.Background(), "1", domain.UpdateMowerDTO{Specs: &domain.MowerSpecsPatch{CuttingWidthCm: &width}}, 0)
+
+		assertInvalidFields(t, err, domain.FieldError{Field: "specs.cuttingWidthCm", Message: "must be between 20 and 150"})
+
+		_, err = service.UpdateMower(context.Background(), "1", domain.UpdateMowerDTO{}, 0)
+
+		lmTesting.AssertNoError(t, err)
+	})
+
+	t.Run("catalog: update may keep its own name but not take another one", func(t *testing.T) {
+		service, _ := newService()
+
+		_, err := service.UpdateMower(context.Background(), "1", domain.UpdateMowerDTO{Name: "M-90"}, 0)
+
+		lmTesting.AssertNoError(t, err)
+
+		_, err = service.UpdateMower(context.Background(), "1", domain.UpdateMowerDTO{Name: "M-150"}, 0)
+
+		assertInvalidFields(t, err, domain.FieldError{Field: "name", Message: "is already used by another mower"})
+	})
+}